@@ -0,0 +1,162 @@
+package bankstatement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// camtDocument holds the parts of an ISO 20022 camt.053 document that are
+// used. Namespaces are ignored, so any camt.053 version is accepted.
+type camtDocument struct {
+	Statements []struct {
+		Account struct {
+			IBAN  string `xml:"Id>IBAN"`
+			Other string `xml:"Id>Othr>Id"`
+			Ccy   string `xml:"Ccy"`
+		} `xml:"Acct"`
+		Balances []struct {
+			Type   string     `xml:"Tp>CdOrPrtry>Cd"`
+			Amount camtAmount `xml:"Amt"`
+			CdtDbt string     `xml:"CdtDbtInd"`
+		} `xml:"Bal"`
+		Entries []struct {
+			Amount      camtAmount `xml:"Amt"`
+			CdtDbt      string     `xml:"CdtDbtInd"`
+			Status      camtStatus `xml:"Sts"`
+			BookingDate camtDate   `xml:"BookgDt"`
+			ValueDate   camtDate   `xml:"ValDt"`
+			ServicerRef string     `xml:"AcctSvcrRef"`
+			Info        string     `xml:"AddtlNtryInf"`
+			Details     []struct {
+				Debtor          string `xml:"RltdPties>Dbtr>Nm"`
+				DebtorParty     string `xml:"RltdPties>Dbtr>Pty>Nm"`
+				DebtorIBAN      string `xml:"RltdPties>DbtrAcct>Id>IBAN"`
+				DebtorAccount   string `xml:"RltdPties>DbtrAcct>Id>Othr>Id"`
+				Creditor        string `xml:"RltdPties>Cdtr>Nm"`
+				CreditorParty   string `xml:"RltdPties>Cdtr>Pty>Nm"`
+				CreditorIBAN    string `xml:"RltdPties>CdtrAcct>Id>IBAN"`
+				CreditorAccount string `xml:"RltdPties>CdtrAcct>Id>Othr>Id"`
+				Structured      string `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+				Unstructured    string `xml:"RmtInf>Ustrd"`
+			} `xml:"NtryDtls>TxDtls"`
+		} `xml:"Ntry"`
+	} `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtAmount struct {
+	Value string `xml:",chardata"`
+	Ccy   string `xml:"Ccy,attr"`
+}
+
+// camtStatus is an entry status, given directly in camt.053.001.02 and as a
+// code in later versions.
+type camtStatus struct {
+	Value string `xml:",chardata"`
+	Code  string `xml:"Cd"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+func (d camtDate) String() string {
+	if d.Date != "" {
+		return d.Date
+	}
+	if len(d.DateTime) >= 10 {
+		return d.DateTime[:10]
+	}
+	return ""
+}
+
+// ParseCAMT053 parses an ISO 20022 camt.053 bank-to-customer statement.
+// Only booked entries are included; debit entries get a negative amount.
+func ParseCAMT053(data []byte) (Statement, error) {
+	var doc camtDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return Statement{}, fmt.Errorf("parsing camt.053: %w", err)
+	}
+	if len(doc.Statements) == 0 {
+		return Statement{}, fmt.Errorf("parsing camt.053: no statement (BkToCstmrStmt/Stmt) found")
+	}
+
+	st := Statement{Lines: []Line{}}
+	for _, s := range doc.Statements {
+		if st.AccountNumber == "" {
+			st.AccountNumber = joinNonEmpty("", s.Account.IBAN, s.Account.Other)
+			st.Currency = s.Account.Ccy
+		}
+		for _, b := range s.Balances {
+			amount, err := signedAmount(b.Amount.Value, b.CdtDbt)
+			if err != nil {
+				return Statement{}, err
+			}
+			switch b.Type {
+			case "OPBD", "PRCD":
+				if st.OpeningBalance == nil {
+					st.OpeningBalance = &amount
+				}
+			case "CLBD":
+				st.ClosingBalance = &amount
+			}
+		}
+		for _, e := range s.Entries {
+			status := strings.TrimSpace(e.Status.Value)
+			if e.Status.Code != "" {
+				status = e.Status.Code
+			}
+			if status != "" && status != "BOOK" {
+				continue
+			}
+			amount, err := signedAmount(e.Amount.Value, e.CdtDbt)
+			if err != nil {
+				return Statement{}, err
+			}
+			if st.Currency == "" {
+				st.Currency = e.Amount.Ccy
+			}
+			line := Line{
+				Date:        e.BookingDate.String(),
+				ValueDate:   e.ValueDate.String(),
+				Amount:      amount,
+				Description: e.Info,
+				Reference:   e.ServicerRef,
+			}
+			if len(e.Details) > 0 {
+				d := e.Details[0]
+				// The counterparty is the debtor for incoming payments and the
+				// creditor for outgoing ones.
+				if amount >= 0 {
+					line.Counterparty = joinNonEmpty("", d.Debtor, d.DebtorParty)
+					line.CounterpartyAccount = joinNonEmpty("", d.DebtorIBAN, d.DebtorAccount)
+				} else {
+					line.Counterparty = joinNonEmpty("", d.Creditor, d.CreditorParty)
+					line.CounterpartyAccount = joinNonEmpty("", d.CreditorIBAN, d.CreditorAccount)
+				}
+				line.KID = d.Structured
+				line.Description = joinNonEmpty(" ", line.Description, d.Unstructured)
+			}
+			if line.KID == "" {
+				line.KID = kidFromText(line.Description)
+			}
+			st.Lines = append(st.Lines, line)
+		}
+	}
+	return st, nil
+}
+
+// signedAmount parses a camt amount, negating it for debit (DBIT) entries.
+func signedAmount(value, cdtDbt string) (fiken.Amount, error) {
+	amount, err := fiken.ParseAmount(value)
+	if err != nil {
+		return 0, fmt.Errorf("parsing camt.053: %w", err)
+	}
+	if cdtDbt == "DBIT" {
+		amount = -amount
+	}
+	return amount, nil
+}
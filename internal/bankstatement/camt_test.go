@@ -0,0 +1,59 @@
+package bankstatement
+
+import "testing"
+
+const camtSample = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Acct><Id><IBAN>NO9386011117947</IBAN></Id><Ccy>NOK</Ccy></Acct>
+      <Bal><Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp><Amt Ccy="NOK">1000.00</Amt><CdtDbtInd>CRDT</CdtDbtInd></Bal>
+      <Bal><Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp><Amt Ccy="NOK">1875.50</Amt><CdtDbtInd>CRDT</CdtDbtInd></Bal>
+      <Ntry>
+        <Amt Ccy="NOK">1250.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Sts>BOOK</Sts>
+        <BookgDt><Dt>2026-03-02</Dt></BookgDt><ValDt><Dt>2026-03-02</Dt></ValDt>
+        <AcctSvcrRef>A1</AcctSvcrRef>
+        <NtryDtls><TxDtls>
+          <RltdPties><Dbtr><Nm>Kari Nordmann</Nm></Dbtr><DbtrAcct><Id><Othr><Id>12345678903</Id></Othr></Id></DbtrAcct></RltdPties>
+          <RmtInf><Strd><CdtrRefInf><Ref>0000123455</Ref></CdtrRefInf></Strd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="NOK">374.50</Amt><CdtDbtInd>DBIT</CdtDbtInd><Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><DtTm>2026-03-03T10:00:00</DtTm></BookgDt>
+        <AddtlNtryInf>Strøm mars</AddtlNtryInf>
+        <NtryDtls><TxDtls><RltdPties><Cdtr><Nm>Kraft AS</Nm></Cdtr></RltdPties></TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="NOK">99.00</Amt><CdtDbtInd>DBIT</CdtDbtInd><Sts>PDNG</Sts>
+        <BookgDt><Dt>2026-03-04</Dt></BookgDt>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+
+func TestParseCAMT053(t *testing.T) {
+	st, err := Parse([]byte(camtSample), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if st.Format != FormatCAMT053 || st.AccountNumber != "NO9386011117947" || st.Currency != "NOK" {
+		t.Errorf("unexpected statement header: %+v", st)
+	}
+	if st.OpeningBalance == nil || *st.OpeningBalance != 100000 || st.ClosingBalance == nil || *st.ClosingBalance != 187550 {
+		t.Errorf("unexpected balances: %v %v", st.OpeningBalance, st.ClosingBalance)
+	}
+	if len(st.Lines) != 2 {
+		t.Fatalf("expected 2 booked lines, got %d", len(st.Lines))
+	}
+	in, out := st.Lines[0], st.Lines[1]
+	if in.Amount != 125000 || in.KID != "0000123455" || in.Counterparty != "Kari Nordmann" || in.CounterpartyAccount != "12345678903" {
+		t.Errorf("unexpected incoming line: %+v", in)
+	}
+	if out.Amount != -37450 || out.Date != "2026-03-03" || out.Counterparty != "Kraft AS" || out.Description != "Strøm mars" {
+		t.Errorf("unexpected outgoing line: %+v", out)
+	}
+	if st.From != "2026-03-02" || st.To != "2026-03-03" {
+		t.Errorf("unexpected date range %s–%s", st.From, st.To)
+	}
+}
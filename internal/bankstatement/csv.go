@@ -0,0 +1,201 @@
+package bankstatement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// csvColumns lists the header names, lower-cased, that Norwegian banks use
+// for each column in their CSV exports (DNB, Nordea, SpareBank 1, Sbanken,
+// Handelsbanken and others).
+var csvColumns = map[string][]string{
+	"date":         {"dato", "bokført dato", "bokføringsdato", "bokført", "transaksjonsdato", "date", "booking date"},
+	"valueDate":    {"rentedato", "valuteringsdato", "valutadato", "value date"},
+	"description":  {"forklaring", "beskrivelse", "tekst", "tittel", "melding", "transaksjonstekst", "description", "text"},
+	"amount":       {"beløp", "beløp inn/ut", "beløp i nok", "amount"},
+	"in":           {"inn", "innskudd", "inn på konto", "kredit", "credit"},
+	"out":          {"ut", "uttak", "ut fra konto", "debet", "debit"},
+	"counterparty": {"navn", "motpart", "mottaker/avsender", "betaler/mottaker", "counterparty"},
+	"account":      {"motkonto", "fra/til konto", "kontonummer motpart"},
+	"kid":          {"kid"},
+	"reference":    {"arkivref", "arkivreferanse", "referanse", "reference"},
+}
+
+// ParseCSV parses a bank CSV export with a header row. The delimiter (";",
+// "," or tab) is detected from the header, and columns are recognised by the
+// header names in csvColumns. Amounts are either one signed column or
+// separate in and out columns; decimal commas and thousands separators are
+// handled.
+func ParseCSV(data []byte) (Statement, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	delimiter := ';'
+	if bytes.Count(header, []byte("\t")) > bytes.Count(header, []byte(";")) {
+		delimiter = '\t'
+	} else if bytes.Count(header, []byte(";")) == 0 && bytes.Count(header, []byte(",")) > 0 {
+		delimiter = ','
+	}
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = delimiter
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return Statement{}, fmt.Errorf("parsing CSV: %w", err)
+	}
+	if len(records) < 1 {
+		return Statement{}, fmt.Errorf("parsing CSV: the file is empty")
+	}
+
+	cols := make(map[string]int)
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		for col, names := range csvColumns {
+			if _, ok := cols[col]; ok {
+				continue
+			}
+			for _, n := range names {
+				if name == n {
+					cols[col] = i
+				}
+			}
+		}
+	}
+	if _, ok := cols["date"]; !ok {
+		return Statement{}, fmt.Errorf("parsing CSV: no date column found in header %q", strings.Join(records[0], string(delimiter)))
+	}
+	_, hasAmount := cols["amount"]
+	_, hasIn := cols["in"]
+	_, hasOut := cols["out"]
+	if !hasAmount && !hasIn && !hasOut {
+		return Statement{}, fmt.Errorf("parsing CSV: no amount, in or out column found in header %q", strings.Join(records[0], string(delimiter)))
+	}
+
+	st := Statement{Lines: []Line{}}
+	for n, rec := range records[1:] {
+		field := func(col string) string {
+			i, ok := cols[col]
+			if !ok || i >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[i])
+		}
+		if field("date") == "" {
+			continue
+		}
+		row := n + 2
+		date, err := parseCSVDate(field("date"))
+		if err != nil {
+			return Statement{}, fmt.Errorf("parsing CSV row %d: %w", row, err)
+		}
+		var amount fiken.Amount
+		if hasAmount && field("amount") != "" {
+			if amount, err = parseCSVAmount(field("amount")); err != nil {
+				return Statement{}, fmt.Errorf("parsing CSV row %d: %w", row, err)
+			}
+		} else {
+			if v := field("in"); v != "" {
+				in, err := parseCSVAmount(v)
+				if err != nil {
+					return Statement{}, fmt.Errorf("parsing CSV row %d: %w", row, err)
+				}
+				amount += abs(in)
+			}
+			if v := field("out"); v != "" {
+				out, err := parseCSVAmount(v)
+				if err != nil {
+					return Statement{}, fmt.Errorf("parsing CSV row %d: %w", row, err)
+				}
+				amount -= abs(out)
+			}
+		}
+		line := Line{
+			Date:                date,
+			Amount:              amount,
+			Description:         field("description"),
+			Counterparty:        field("counterparty"),
+			CounterpartyAccount: field("account"),
+			KID:                 field("kid"),
+			Reference:           field("reference"),
+		}
+		if v := field("valueDate"); v != "" {
+			if line.ValueDate, err = parseCSVDate(v); err != nil {
+				return Statement{}, fmt.Errorf("parsing CSV row %d: %w", row, err)
+			}
+		}
+		if line.KID == "" {
+			line.KID = kidFromText(line.Description)
+		}
+		st.Lines = append(st.Lines, line)
+	}
+	return st, nil
+}
+
+var csvDateLayouts = []string{"02.01.2006", "2006-01-02", "2006/01/02", "02.01.06", "02/01/2006"}
+
+// parseCSVDate parses the date formats used in Norwegian bank exports.
+func parseCSVDate(s string) (string, error) {
+	for _, layout := range csvDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format("2006-01-02"), nil
+		}
+	}
+	return "", fmt.Errorf("invalid date %q", s)
+}
+
+// groupedAmounts match whole amounts with dots or commas as thousands
+// separators, such as "1.234" and "-12,345,678".
+var groupedAmounts = map[byte]*regexp.Regexp{
+	'.': regexp.MustCompile(`^[+-]?\d{1,3}(\.\d{3})+$`),
+	',': regexp.MustCompile(`^[+-]?\d{1,3}(,\d{3})+$`),
+}
+
+// parseCSVAmount parses amounts such as "1 234,56", "-1.234,56",
+// "1,234.56", "1.234" and "1234.56". The last separator is the decimal
+// separator when one or two digits follow it, and the other may only group
+// thousands. Three digits after the last separator mean thousands only for
+// a dot grouping ("1.234") or repeated commas ("1,234,567"). Anything else,
+// such as "1234.567", "1,234" or mixed separators, is rejected as ambiguous.
+func parseCSVAmount(s string) (fiken.Amount, error) {
+	s = strings.NewReplacer(" ", "", "\u00a0", "", "kr", "", "NOK", "").Replace(s)
+	last := strings.LastIndexAny(s, ".,")
+	if last < 0 {
+		return fiken.ParseAmount(s)
+	}
+	sep, other := s[last], byte(',')
+	if sep == ',' {
+		other = '.'
+	}
+	whole, frac := s[:last], s[last+1:]
+	ambiguous := fmt.Errorf("ambiguous amount %q: cannot tell the decimal separator from thousands separators", s)
+	switch {
+	case len(frac) == 1 || len(frac) == 2:
+		if strings.IndexByte(whole, sep) >= 0 {
+			return 0, ambiguous
+		}
+		if strings.IndexByte(whole, other) >= 0 {
+			if !groupedAmounts[other].MatchString(whole) {
+				return 0, ambiguous
+			}
+			whole = strings.ReplaceAll(whole, string(other), "")
+		}
+		return fiken.ParseAmount(whole + "." + frac)
+	case len(frac) == 3 && groupedAmounts[sep].MatchString(s) && (sep == '.' || strings.Count(s, ",") > 1):
+		return fiken.ParseAmount(strings.ReplaceAll(s, string(sep), ""))
+	}
+	return 0, ambiguous
+}
+
+func abs(a fiken.Amount) fiken.Amount {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
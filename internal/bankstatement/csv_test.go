@@ -0,0 +1,89 @@
+package bankstatement
+
+import (
+	"testing"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "in and out columns with decimal comma",
+			data: "\ufeff\"Dato\";\"Forklaring\";\"Rentedato\";\"Uttak\";\"Innskudd\"\n" +
+				"\"02.03.2026\";\"Kari Nordmann KID: 0000123455\";\"02.03.2026\";\"\";\"1 250,00\"\n" +
+				"\"03.03.2026\";\"Kraft AS\";\"03.03.2026\";\"374,50\";\"\"\n",
+		},
+		{
+			name: "signed amount column",
+			data: "Bokføringsdato;Beløp;Navn;Tittel;KID\n" +
+				"2026/03/02;1.250,00;Kari Nordmann;Innbetaling;0000123455\n" +
+				"2026/03/03;-374,50;Kraft AS;Strøm;\n",
+		},
+		{
+			name: "comma separated",
+			data: "Date,Description,Amount\n2026-03-02,KID 0000123455,1250.00\n2026-03-03,Kraft AS,-374.50\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, err := Parse([]byte(tt.data), "")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if st.Format != FormatCSV || len(st.Lines) != 2 {
+				t.Fatalf("expected 2 CSV lines, got %+v", st)
+			}
+			if l := st.Lines[0]; l.Date != "2026-03-02" || l.Amount != 125000 || l.KID != "0000123455" {
+				t.Errorf("unexpected first line: %+v", l)
+			}
+			if l := st.Lines[1]; l.Date != "2026-03-03" || l.Amount != -37450 {
+				t.Errorf("unexpected second line: %+v", l)
+			}
+		})
+	}
+}
+
+func TestParseCSVAmount(t *testing.T) {
+	tests := []struct {
+		input string
+		want  fiken.Amount
+	}{
+		{"1 234,56", 123456},
+		{"-1.234,56", -123456},
+		{"1234.56", 123456},
+		{"1.234", 123400},
+		{"-12.345.678", -1234567800},
+		{"12.5", 1250},
+		{"374,50 kr", 37450},
+		{"1.234,56", 123456},
+		{"1,234.56", 123456},
+		{"-1,234,567.8", -123456780},
+		{"1,234,567", 123456700},
+		{",50", 50},
+	}
+	for _, tt := range tests {
+		got, err := parseCSVAmount(tt.input)
+		if err != nil {
+			t.Errorf("parseCSVAmount(%q): unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseCSVAmount(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+	for _, input := range []string{"1234.567", "1,234", "1.234.56", "1,234,56", "1.23,45", "12,34.567", "1.234,567"} {
+		if got, err := parseCSVAmount(input); err == nil {
+			t.Errorf("parseCSVAmount(%q) = %s, expected an ambiguous amount error", input, got)
+		}
+	}
+}
+
+func TestParseCSVRejectsUnknownHeader(t *testing.T) {
+	if _, err := ParseCSV([]byte("foo;bar\n1;2\n")); err == nil {
+		t.Error("expected an error for a header without a date column")
+	}
+}
@@ -0,0 +1,177 @@
+package bankstatement
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// Candidate types.
+const (
+	CandidateInvoice  = "invoice"
+	CandidatePurchase = "purchase"
+	// CandidateSaleDraft and CandidatePurchaseDraft are transactions not yet
+	// posted in Fiken; a match suggests booking the draft with the payment.
+	CandidateSaleDraft     = "sale_draft"
+	CandidatePurchaseDraft = "purchase_draft"
+)
+
+// Candidate is something a statement line may settle or correspond to.
+// Amount is signed as it would appear on the statement: positive for
+// invoices and sale drafts, negative for purchases and purchase drafts.
+type Candidate struct {
+	Type         string       `json:"type"`
+	ID           int64        `json:"id"`
+	Number       string       `json:"number,omitempty"`
+	Date         string       `json:"date"`
+	DueDate      string       `json:"dueDate,omitempty"`
+	Amount       fiken.Amount `json:"amount"`
+	KID          string       `json:"kid,omitempty"`
+	Counterparty string       `json:"counterparty,omitempty"`
+	Description  string       `json:"description,omitempty"`
+}
+
+// ScoredCandidate is a candidate with its match score (0–100) and the
+// reasons behind it.
+type ScoredCandidate struct {
+	Candidate
+	Score   int      `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
+// LineMatches is a statement line with its ranked candidates.
+type LineMatches struct {
+	Line       Line              `json:"line"`
+	Candidates []ScoredCandidate `json:"candidates"`
+}
+
+// MatchOptions controls matching.
+type MatchOptions struct {
+	// DateWindowDays is how far, in days, a line may be from a candidate's
+	// due date (or date, when it has none) to score on date.
+	DateWindowDays int
+	// MaxCandidates limits the candidates returned per line.
+	MaxCandidates int
+}
+
+// Score weights.
+const (
+	scoreAmount       = 45
+	scoreKID          = 35
+	scoreCounterparty = 10
+	scoreDate         = 10
+)
+
+// Match ranks candidates for each statement line. A candidate is only
+// considered when its amount equals the line's or their KIDs match (which
+// allows part payments). The KID, counterparty name and date proximity
+// raise the score.
+func Match(lines []Line, candidates []Candidate, opts MatchOptions) []LineMatches {
+	if opts.MaxCandidates <= 0 {
+		opts.MaxCandidates = 3
+	}
+	result := make([]LineMatches, 0, len(lines))
+	for _, l := range lines {
+		lm := LineMatches{Line: l, Candidates: []ScoredCandidate{}}
+		for _, c := range candidates {
+			if sc, ok := score(l, c, opts.DateWindowDays); ok {
+				lm.Candidates = append(lm.Candidates, sc)
+			}
+		}
+		sort.SliceStable(lm.Candidates, func(i, j int) bool { return lm.Candidates[i].Score > lm.Candidates[j].Score })
+		if len(lm.Candidates) > opts.MaxCandidates {
+			lm.Candidates = lm.Candidates[:opts.MaxCandidates]
+		}
+		result = append(result, lm)
+	}
+	return result
+}
+
+func score(l Line, c Candidate, window int) (ScoredCandidate, bool) {
+	sc := ScoredCandidate{Candidate: c, Reasons: []string{}}
+	amountMatch := l.Amount == c.Amount
+	kidMatch := l.KID != "" && c.KID != "" && strings.TrimLeft(l.KID, "0") == strings.TrimLeft(c.KID, "0")
+	if !amountMatch && !kidMatch {
+		return sc, false
+	}
+	if kidMatch && (l.Amount > 0) != (c.Amount > 0) {
+		return sc, false
+	}
+
+	days, hasDays := daysBetween(l.Date, c.DueDate)
+	if !hasDays {
+		days, hasDays = daysBetween(l.Date, c.Date)
+	}
+
+	if amountMatch {
+		sc.Score += scoreAmount
+		sc.Reasons = append(sc.Reasons, "amount matches")
+	} else {
+		sc.Reasons = append(sc.Reasons, fmt.Sprintf("part payment: %s of %s", l.Amount, c.Amount))
+	}
+	if kidMatch {
+		sc.Score += scoreKID
+		sc.Reasons = append(sc.Reasons, "KID matches")
+	}
+	if similarNames(l.Counterparty, c.Counterparty) {
+		sc.Score += scoreCounterparty
+		sc.Reasons = append(sc.Reasons, "counterparty matches")
+	}
+	if hasDays && days <= window {
+		// Full date score on the day, falling linearly to zero at the window edge.
+		sc.Score += scoreDate * (window + 1 - days) / (window + 1)
+		sc.Reasons = append(sc.Reasons, fmt.Sprintf("%d days from %s", days, dateKind(c)))
+	}
+	return sc, true
+}
+
+func dateKind(c Candidate) string {
+	if c.DueDate != "" {
+		return "due date"
+	}
+	return "date"
+}
+
+// daysBetween returns the absolute number of days between two dates.
+func daysBetween(a, b string) (int, bool) {
+	ta, errA := time.Parse("2006-01-02", a)
+	tb, errB := time.Parse("2006-01-02", b)
+	if errA != nil || errB != nil {
+		return 0, false
+	}
+	days := int(ta.Sub(tb).Hours() / 24)
+	if days < 0 {
+		days = -days
+	}
+	return days, true
+}
+
+// companySuffixes are dropped when comparing names.
+var companySuffixes = map[string]bool{"as": true, "asa": true, "ab": true, "ans": true, "da": true, "enk": true, "sa": true, "ltd": true, "inc": true}
+
+// similarNames reports whether two names share a significant word, ignoring
+// case, punctuation and company suffixes such as AS.
+func similarNames(a, b string) bool {
+	words := func(s string) []string {
+		var out []string
+		for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+			if len(w) > 1 && !companySuffixes[w] {
+				out = append(out, w)
+			}
+		}
+		return out
+	}
+	wb := words(b)
+	for _, x := range words(a) {
+		for _, y := range wb {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
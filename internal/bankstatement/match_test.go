@@ -0,0 +1,34 @@
+package bankstatement
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	lines := []Line{
+		{Date: "2026-03-02", Amount: 125000, KID: "0000123455", Counterparty: "Kari Nordmann"},
+		{Date: "2026-03-03", Amount: -37450, Counterparty: "Kraft AS"},
+		{Date: "2026-03-05", Amount: 50000, KID: "0000999993"},
+	}
+	candidates := []Candidate{
+		{Type: CandidateInvoice, ID: 1, Date: "2026-02-15", DueDate: "2026-03-01", Amount: 125000, KID: "123455", Counterparty: "Kari Nordmann"},
+		{Type: CandidateInvoice, ID: 2, Date: "2026-01-10", DueDate: "2026-01-24", Amount: 125000, Counterparty: "Ola AS"},
+		{Type: CandidatePurchase, ID: 3, Date: "2026-02-20", DueDate: "2026-03-05", Amount: -37450, Counterparty: "Kraft Norge AS"},
+		{Type: CandidatePurchaseDraft, ID: 4, Date: "2026-01-02", Amount: -37450, Counterparty: "Annen Leverandør AS"},
+		{Type: CandidateInvoice, ID: 5, Date: "2026-02-20", DueDate: "2026-03-06", Amount: 100000, KID: "999993"},
+	}
+
+	got := Match(lines, candidates, MatchOptions{DateWindowDays: 7})
+
+	first := got[0].Candidates
+	if len(first) != 2 || first[0].ID != 1 || first[1].ID != 2 || first[0].Score <= first[1].Score {
+		t.Fatalf("expected invoice 1 ranked above invoice 2, got %+v", first)
+	}
+	if first[0].Score != scoreAmount+scoreKID+scoreCounterparty+scoreDate*7/8 {
+		t.Errorf("unexpected score %d for invoice 1: %v", first[0].Score, first[0].Reasons)
+	}
+	if second := got[1].Candidates; len(second) != 2 || second[0].ID != 3 || second[1].ID != 4 || second[1].Score != scoreAmount {
+		t.Errorf("expected purchase 3 ranked above purchase draft 4, got %+v", second)
+	}
+	if third := got[2].Candidates; len(third) != 1 || third[0].ID != 5 || third[0].Reasons[0] != "part payment: 500.00 of 1000.00" {
+		t.Errorf("expected a part payment on invoice 5, got %+v", third)
+	}
+}
@@ -0,0 +1,107 @@
+package bankstatement
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// ofxTag matches an OFX tag with an optional value. OFX 1.x is SGML where
+// leaf elements are not closed, so the value runs to the next tag or line end.
+var ofxTag = regexp.MustCompile(`<(/?)([A-Za-z0-9.]+)>([^<\r\n]*)`)
+
+// ParseOFX parses the bank statement transactions of an OFX 1.x (SGML) or
+// 2.x (XML) file.
+func ParseOFX(data []byte) (Statement, error) {
+	st := Statement{Lines: []Line{}}
+	var (
+		txn      *ofxTransaction
+		inLedger bool
+		found    bool
+	)
+	for _, m := range ofxTag.FindAllStringSubmatch(string(data), -1) {
+		closing, tag, value := m[1] == "/", strings.ToUpper(m[2]), strings.TrimSpace(m[3])
+		switch {
+		case tag == "STMTTRN" && !closing:
+			txn = &ofxTransaction{}
+			found = true
+		case tag == "STMTTRN" && closing:
+			if txn != nil {
+				line, err := txn.line()
+				if err != nil {
+					return Statement{}, err
+				}
+				st.Lines = append(st.Lines, line)
+			}
+			txn = nil
+		case tag == "LEDGERBAL":
+			inLedger = !closing
+		case closing || value == "":
+			continue
+		case txn != nil:
+			txn.set(tag, value)
+		case tag == "ACCTID":
+			st.AccountNumber = value
+		case tag == "CURDEF":
+			st.Currency = value
+		case tag == "BALAMT" && inLedger:
+			amount, err := fiken.ParseAmount(value)
+			if err != nil {
+				return Statement{}, fmt.Errorf("parsing OFX ledger balance: %w", err)
+			}
+			st.ClosingBalance = &amount
+		}
+	}
+	if !found && st.AccountNumber == "" {
+		return Statement{}, fmt.Errorf("parsing OFX: no statement transactions found")
+	}
+	return st, nil
+}
+
+type ofxTransaction struct {
+	posted, amount, id, name, memo, refnum string
+}
+
+func (t *ofxTransaction) set(tag, value string) {
+	switch tag {
+	case "DTPOSTED":
+		t.posted = value
+	case "TRNAMT":
+		t.amount = value
+	case "FITID":
+		t.id = value
+	case "NAME":
+		t.name = value
+	case "MEMO":
+		t.memo = value
+	case "REFNUM":
+		t.refnum = value
+	}
+}
+
+func (t *ofxTransaction) line() (Line, error) {
+	amount, err := fiken.ParseAmount(strings.Replace(t.amount, ",", ".", 1))
+	if err != nil {
+		return Line{}, fmt.Errorf("parsing OFX transaction %s: %w", t.id, err)
+	}
+	date := t.posted
+	if len(date) < 8 {
+		return Line{}, fmt.Errorf("parsing OFX transaction %s: invalid DTPOSTED %q", t.id, t.posted)
+	}
+	// DTPOSTED is YYYYMMDD optionally followed by a time and zone.
+	date = date[0:4] + "-" + date[4:6] + "-" + date[6:8]
+	reference := t.refnum
+	if reference == "" {
+		reference = t.id
+	}
+	return Line{
+		Date:         date,
+		Amount:       amount,
+		Description:  t.memo,
+		Counterparty: t.name,
+		KID:          kidFromText(t.memo),
+		Reference:    reference,
+	}, nil
+}
@@ -0,0 +1,55 @@
+package bankstatement
+
+import "testing"
+
+const ofxSample = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>NOK
+<BANKACCTFROM><BANKID>1234<ACCTID>12345678903<ACCTTYPE>CHECKING</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>20260301<DTEND>20260331
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20260302120000[+1:CET]
+<TRNAMT>1250.00
+<FITID>F1
+<NAME>Kari Nordmann
+<MEMO>Betaling KID: 0000123455
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20260303
+<TRNAMT>-374.50
+<FITID>F2
+<NAME>Kraft AS
+</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL><BALAMT>1875.50<DTASOF>20260331</LEDGERBAL>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>`
+
+func TestParseOFX(t *testing.T) {
+	st, err := Parse([]byte(ofxSample), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if st.Format != FormatOFX || st.AccountNumber != "12345678903" || st.Currency != "NOK" {
+		t.Errorf("unexpected statement header: %+v", st)
+	}
+	if st.ClosingBalance == nil || *st.ClosingBalance != 187550 {
+		t.Errorf("unexpected closing balance: %v", st.ClosingBalance)
+	}
+	if len(st.Lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(st.Lines))
+	}
+	if l := st.Lines[0]; l.Date != "2026-03-02" || l.Amount != 125000 || l.KID != "0000123455" || l.Counterparty != "Kari Nordmann" || l.Reference != "F1" {
+		t.Errorf("unexpected first line: %+v", l)
+	}
+	if l := st.Lines[1]; l.Amount != -37450 || l.Counterparty != "Kraft AS" {
+		t.Errorf("unexpected second line: %+v", l)
+	}
+}
@@ -0,0 +1,103 @@
+package bankstatement
+
+import (
+	"sort"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// Posting is a ledger posting on a bank account's GL code.
+type Posting struct {
+	JournalEntryID     int64        `json:"journalEntryId"`
+	JournalEntryNumber int64        `json:"journalEntryNumber"`
+	Date               string       `json:"date"`
+	Description        string       `json:"description,omitempty"`
+	Amount             fiken.Amount `json:"amount"`
+	// EqualsDifference is set when the posting alone accounts for the
+	// difference being explained.
+	EqualsDifference bool `json:"equalsDifference,omitempty"`
+}
+
+// Reconciliation compares a bank account's ledger balance with the balance
+// reported by the bank and, optionally, a statement's closing balance.
+type Reconciliation struct {
+	AccountCode      string        `json:"accountCode"`
+	Date             string        `json:"date"`
+	LedgerBalance    fiken.Amount  `json:"ledgerBalance"`
+	BankBalance      *fiken.Amount `json:"bankBalance,omitempty"`
+	StatementBalance *fiken.Amount `json:"statementBalance,omitempty"`
+	// DifferenceToBank and DifferenceToStatement are the ledger balance minus
+	// the other balance.
+	DifferenceToBank      *fiken.Amount `json:"differenceToBank,omitempty"`
+	DifferenceToStatement *fiken.Amount `json:"differenceToStatement,omitempty"`
+	// Reconciled is true when at least one of the bank and statement
+	// balances was given and the ledger balance equals each of them.
+	Reconciled bool `json:"reconciled"`
+	// UnmatchedPostings are recent ledger postings with no statement line of
+	// the same amount within the tolerance, or all recent postings when no
+	// statement lines are given. Explained is true when they add up to the
+	// difference (the statement difference when there is one).
+	UnmatchedPostings []Posting    `json:"unmatchedPostings"`
+	UnmatchedTotal    fiken.Amount `json:"unmatchedTotal"`
+	Explained         bool         `json:"explained"`
+}
+
+// Reconcile compares ledger with the bank and statement balances (either may
+// be nil) and lists the postings that are not on the statement. A statement
+// line matches a posting of the same amount dated at most toleranceDays
+// apart; each line matches one posting.
+func Reconcile(accountCode, date string, ledger fiken.Amount, bank, statement *fiken.Amount, postings []Posting, lines []Line, toleranceDays int) Reconciliation {
+	r := Reconciliation{
+		AccountCode:       accountCode,
+		Date:              date,
+		LedgerBalance:     ledger,
+		BankBalance:       bank,
+		StatementBalance:  statement,
+		UnmatchedPostings: []Posting{},
+	}
+	// With neither balance there is nothing to reconcile against, and the
+	// account is not reported as reconciled.
+	r.Reconciled = bank != nil || statement != nil
+	var difference fiken.Amount
+	if bank != nil {
+		d := ledger - *bank
+		r.DifferenceToBank = &d
+		r.Reconciled = r.Reconciled && d == 0
+		difference = d
+	}
+	if statement != nil {
+		d := ledger - *statement
+		r.DifferenceToStatement = &d
+		r.Reconciled = r.Reconciled && d == 0
+		difference = d
+	}
+
+	sorted := append([]Posting(nil), postings...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Date > sorted[j].Date })
+	used := make([]bool, len(lines))
+	for _, p := range sorted {
+		if matchLine(p, lines, used, toleranceDays) {
+			continue
+		}
+		p.EqualsDifference = difference != 0 && p.Amount == difference
+		r.UnmatchedPostings = append(r.UnmatchedPostings, p)
+		r.UnmatchedTotal += p.Amount
+	}
+	r.Explained = (bank != nil || statement != nil) && !r.Reconciled && r.UnmatchedTotal == difference
+	return r
+}
+
+// matchLine marks and reports the first unused line with the posting's
+// amount within the date tolerance.
+func matchLine(p Posting, lines []Line, used []bool, toleranceDays int) bool {
+	for i, l := range lines {
+		if used[i] || l.Amount != p.Amount {
+			continue
+		}
+		if days, ok := daysBetween(l.Date, p.Date); ok && days <= toleranceDays {
+			used[i] = true
+			return true
+		}
+	}
+	return false
+}
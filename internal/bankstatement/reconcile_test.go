@@ -0,0 +1,56 @@
+package bankstatement
+
+import (
+	"testing"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func TestReconcile(t *testing.T) {
+	bank := fiken.Amount(100000)
+	statement := fiken.Amount(100000)
+	postings := []Posting{
+		{JournalEntryID: 1, Date: "2026-03-02", Amount: 125000},
+		{JournalEntryID: 2, Date: "2026-03-30", Amount: -37450},
+		{JournalEntryID: 3, Date: "2026-03-31", Amount: 20000},
+	}
+	lines := []Line{
+		{Date: "2026-03-03", Amount: 125000},
+	}
+
+	r := Reconcile("1920:10001", "2026-03-31", 82550, &bank, &statement, postings, lines, 3)
+
+	if r.Reconciled || *r.DifferenceToBank != -17450 || *r.DifferenceToStatement != -17450 {
+		t.Errorf("unexpected differences: %+v", r)
+	}
+	if len(r.UnmatchedPostings) != 2 || r.UnmatchedPostings[0].JournalEntryID != 3 || r.UnmatchedTotal != -17450 {
+		t.Fatalf("expected postings 3 and 2 unmatched, got %+v", r.UnmatchedPostings)
+	}
+	if !r.Explained {
+		t.Error("expected the unmatched postings to explain the difference")
+	}
+
+	r = Reconcile("1920:10001", "2026-03-31", 100000, &bank, nil, nil, nil, 3)
+	if !r.Reconciled || r.Explained || r.DifferenceToStatement != nil {
+		t.Errorf("expected a reconciled account, got %+v", r)
+	}
+
+	r = Reconcile("1920:10001", "2026-03-31", 100000, nil, nil, nil, nil, 3)
+	if r.Reconciled || r.Explained {
+		t.Errorf("expected an account with no bank or statement balance not to be reconciled, got %+v", r)
+	}
+}
+
+func TestReconcileFlagsSinglePosting(t *testing.T) {
+	bank := fiken.Amount(0)
+	postings := []Posting{
+		{JournalEntryID: 1, Date: "2026-03-30", Amount: 5000},
+		{JournalEntryID: 2, Date: "2026-03-29", Amount: 1000},
+	}
+
+	r := Reconcile("1920", "2026-03-31", 5000, &bank, nil, postings, nil, 3)
+
+	if !r.UnmatchedPostings[0].EqualsDifference || r.UnmatchedPostings[1].EqualsDifference || r.Explained {
+		t.Errorf("expected only posting 1 to equal the difference, got %+v", r)
+	}
+}
@@ -0,0 +1,121 @@
+// Package bankstatement parses bank statements (CAMT.053, OFX and Norwegian
+// bank CSV exports) and matches their lines against open items in Fiken.
+package bankstatement
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// Statement is a parsed bank statement.
+type Statement struct {
+	Format        string `json:"format"`
+	AccountNumber string `json:"accountNumber,omitempty"`
+	Currency      string `json:"currency,omitempty"`
+	// From and To are the first and last booking dates (YYYY-MM-DD).
+	From           string        `json:"from,omitempty"`
+	To             string        `json:"to,omitempty"`
+	OpeningBalance *fiken.Amount `json:"openingBalance,omitempty"`
+	ClosingBalance *fiken.Amount `json:"closingBalance,omitempty"`
+	Lines          []Line        `json:"lines,omitempty"`
+}
+
+// Line is one booked statement entry. Amount is positive for money into the
+// account and negative for money out.
+type Line struct {
+	Date                string       `json:"date"`
+	ValueDate           string       `json:"valueDate,omitempty"`
+	Amount              fiken.Amount `json:"amount"`
+	Description         string       `json:"description,omitempty"`
+	Counterparty        string       `json:"counterparty,omitempty"`
+	CounterpartyAccount string       `json:"counterpartyAccount,omitempty"`
+	KID                 string       `json:"kid,omitempty"`
+	Reference           string       `json:"reference,omitempty"`
+}
+
+// Formats accepted by Parse.
+const (
+	FormatCAMT053 = "camt053"
+	FormatOFX     = "ofx"
+	FormatCSV     = "csv"
+)
+
+// Parse parses a statement in the given format, or detects the format when
+// it is empty.
+func Parse(data []byte, format string) (Statement, error) {
+	if format == "" {
+		format = DetectFormat(data)
+	}
+	var (
+		st  Statement
+		err error
+	)
+	switch format {
+	case FormatCAMT053:
+		st, err = ParseCAMT053(data)
+	case FormatOFX:
+		st, err = ParseOFX(data)
+	case FormatCSV:
+		st, err = ParseCSV(data)
+	default:
+		return Statement{}, fmt.Errorf("unknown statement format %q, expected camt053, ofx or csv", format)
+	}
+	if err != nil {
+		return Statement{}, err
+	}
+	st.Format = format
+	st.setDateRange()
+	return st, nil
+}
+
+// DetectFormat guesses the format of a statement from its content.
+func DetectFormat(data []byte) string {
+	head := data
+	if len(head) > 4096 {
+		head = head[:4096]
+	}
+	switch {
+	case bytes.Contains(head, []byte("camt.053")) || bytes.Contains(head, []byte("<BkToCstmrStmt")):
+		return FormatCAMT053
+	case bytes.Contains(head, []byte("OFXHEADER")) || bytes.Contains(bytes.ToUpper(head), []byte("<OFX>")):
+		return FormatOFX
+	default:
+		return FormatCSV
+	}
+}
+
+func (st *Statement) setDateRange() {
+	for _, l := range st.Lines {
+		if st.From == "" || l.Date < st.From {
+			st.From = l.Date
+		}
+		if l.Date > st.To {
+			st.To = l.Date
+		}
+	}
+}
+
+var kidPattern = regexp.MustCompile(`(?i)\bKID\b[:.\s]*([0-9]{2,25})\b`)
+
+// kidFromText extracts a KID written as "KID: 123..." in free text.
+func kidFromText(s string) string {
+	if m := kidPattern.FindStringSubmatch(s); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// joinNonEmpty joins the non-empty, trimmed parts with sep.
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, sep)
+}
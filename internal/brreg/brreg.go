@@ -0,0 +1,169 @@
+// Package brreg looks up organizations in the Brønnøysund Register Centre's
+// Central Coordinating Register for Legal Entities (Enhetsregisteret).
+package brreg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// DefaultBaseURL is the public Enhetsregisteret API.
+const DefaultBaseURL = "https://data.brreg.no/enhetsregisteret/api"
+
+// ErrNotFound is returned when no main unit or sub-unit has the organization
+// number.
+var ErrNotFound = errors.New("organization number not found in Enhetsregisteret")
+
+// Entity is a registered main unit (enhet) or sub-unit (underenhet).
+type Entity struct {
+	OrganizationNumber string `json:"organizationNumber"`
+	Name               string `json:"name"`
+	// OrganizationForm is the form code, e.g. "AS" or "ENK".
+	OrganizationForm string `json:"organizationForm,omitempty"`
+	// SubUnit is set for a sub-unit, whose ParentOrganizationNumber is the
+	// legal entity.
+	SubUnit                  bool           `json:"subUnit,omitempty"`
+	ParentOrganizationNumber string         `json:"parentOrganizationNumber,omitempty"`
+	VatRegistered            bool           `json:"vatRegistered"`
+	Bankrupt                 bool           `json:"bankrupt,omitempty"`
+	UnderLiquidation         bool           `json:"underLiquidation,omitempty"`
+	Deleted                  bool           `json:"deleted,omitempty"`
+	DeletedDate              string         `json:"deletedDate,omitempty"`
+	BusinessAddress          *fiken.Address `json:"businessAddress,omitempty"`
+	PostalAddress            *fiken.Address `json:"postalAddress,omitempty"`
+}
+
+// Address returns the address to use on a contact: the postal address when
+// there is one, otherwise the business address.
+func (e *Entity) Address() *fiken.Address {
+	if e.PostalAddress != nil {
+		return e.PostalAddress
+	}
+	return e.BusinessAddress
+}
+
+// Client is an HTTP client for the Enhetsregisteret API.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a client for the API at baseURL, or DefaultBaseURL when
+// it is empty.
+func NewClient(baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{},
+	}
+}
+
+type apiAddress struct {
+	Address    []string `json:"adresse"`
+	PostalCode string   `json:"postnummer"`
+	City       string   `json:"poststed"`
+	Country    string   `json:"land"`
+}
+
+type apiEntity struct {
+	OrganizationNumber string `json:"organisasjonsnummer"`
+	Name               string `json:"navn"`
+	OrganizationForm   struct {
+		Code string `json:"kode"`
+	} `json:"organisasjonsform"`
+	VatRegistered    bool        `json:"registrertIMvaregisteret"`
+	Bankrupt         bool        `json:"konkurs"`
+	UnderLiquidation bool        `json:"underAvvikling"`
+	DeletedDate      string      `json:"slettedato"`
+	Parent           string      `json:"overordnetEnhet"`
+	BusinessAddress  *apiAddress `json:"forretningsadresse"`
+	PostalAddress    *apiAddress `json:"postadresse"`
+	// Sub-units have a location address instead of a business address.
+	LocationAddress *apiAddress `json:"beliggenhetsadresse"`
+}
+
+// Lookup returns the main unit with the organization number, or the sub-unit
+// when there is no main unit with it.
+func (c *Client) Lookup(organizationNumber string) (*Entity, error) {
+	orgnr := fiken.NormalizeIdentifier(organizationNumber)
+	if err := fiken.ValidateOrganizationNumber(orgnr); err != nil {
+		return nil, err
+	}
+	e, err := c.get("/enheter/" + orgnr)
+	if errors.Is(err, ErrNotFound) {
+		if e, err = c.get("/underenheter/" + orgnr); err == nil {
+			e.SubUnit = true
+		}
+	}
+	return e, err
+}
+
+func (c *Client) get(path string) (*Entity, error) {
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case resp.StatusCode == http.StatusGone:
+		// Removed entities answer 410 with the organization number and
+		// deletion date.
+	case resp.StatusCode >= 400:
+		return nil, fmt.Errorf("API error %d from Enhetsregisteret: %s", resp.StatusCode, string(body))
+	}
+
+	var a apiEntity
+	if err := json.Unmarshal(body, &a); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	e := &Entity{
+		OrganizationNumber:       a.OrganizationNumber,
+		Name:                     a.Name,
+		OrganizationForm:         a.OrganizationForm.Code,
+		ParentOrganizationNumber: a.Parent,
+		VatRegistered:            a.VatRegistered,
+		Bankrupt:                 a.Bankrupt,
+		UnderLiquidation:         a.UnderLiquidation,
+		Deleted:                  resp.StatusCode == http.StatusGone || a.DeletedDate != "",
+		DeletedDate:              a.DeletedDate,
+		BusinessAddress:          a.BusinessAddress.toAddress(),
+		PostalAddress:            a.PostalAddress.toAddress(),
+	}
+	if e.BusinessAddress == nil {
+		e.BusinessAddress = a.LocationAddress.toAddress()
+	}
+	return e, nil
+}
+
+func (a *apiAddress) toAddress() *fiken.Address {
+	if a == nil {
+		return nil
+	}
+	addr := &fiken.Address{PostCode: a.PostalCode, City: a.City, Country: a.Country}
+	if len(a.Address) > 0 {
+		addr.StreetAddress = a.Address[0]
+	}
+	if len(a.Address) > 1 {
+		addr.StreetAddressLine2 = strings.Join(a.Address[1:], ", ")
+	}
+	return addr
+}
@@ -0,0 +1,83 @@
+package brreg
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestServer(t *testing.T) *Client {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/enheter/923609016", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"organisasjonsnummer": "923609016",
+			"navn": "EQUINOR ASA",
+			"organisasjonsform": {"kode": "ASA", "beskrivelse": "Allmennaksjeselskap"},
+			"forretningsadresse": {"land": "Norge", "landkode": "NO", "postnummer": "4035", "poststed": "STAVANGER", "adresse": ["Forusbeen 50"]},
+			"registrertIMvaregisteret": true,
+			"konkurs": false,
+			"underAvvikling": false
+		}`))
+	})
+	mux.HandleFunc("/underenheter/974760673", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"organisasjonsnummer": "974760673",
+			"navn": "REGISTERENHETEN I BRØNNØYSUND",
+			"overordnetEnhet": "912660680",
+			"beliggenhetsadresse": {"land": "Norge", "postnummer": "8900", "poststed": "BRØNNØYSUND", "adresse": ["Havnegata 48"]},
+			"postadresse": {"land": "Norge", "postnummer": "8910", "poststed": "BRØNNØYSUND", "adresse": ["Postboks 900", "Att: Regnskap"]}
+		}`))
+	})
+	mux.HandleFunc("/enheter/986180516", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+		w.Write([]byte(`{"organisasjonsnummer": "986180516", "slettedato": "2020-05-04"}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL + "/")
+}
+
+func TestLookup(t *testing.T) {
+	c := newTestServer(t)
+
+	e, err := c.Lookup("923 609 016")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.Name != "EQUINOR ASA" || e.OrganizationForm != "ASA" || !e.VatRegistered || e.SubUnit {
+		t.Errorf("unexpected entity: %+v", e)
+	}
+	if a := e.Address(); a == nil || a.StreetAddress != "Forusbeen 50" || a.PostCode != "4035" || a.City != "STAVANGER" || a.Country != "Norge" {
+		t.Errorf("unexpected address: %+v", a)
+	}
+}
+
+func TestLookupSubUnit(t *testing.T) {
+	e, err := newTestServer(t).Lookup("974760673")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !e.SubUnit || e.ParentOrganizationNumber != "912660680" || e.BusinessAddress.StreetAddress != "Havnegata 48" {
+		t.Errorf("unexpected sub-unit: %+v", e)
+	}
+	if a := e.Address(); a.StreetAddress != "Postboks 900" || a.StreetAddressLine2 != "Att: Regnskap" {
+		t.Errorf("expected the postal address, got %+v", a)
+	}
+}
+
+func TestLookupDeletedAndMissing(t *testing.T) {
+	c := newTestServer(t)
+
+	e, err := c.Lookup("986180516")
+	if err != nil || !e.Deleted || e.DeletedDate != "2020-05-04" {
+		t.Errorf("expected a deleted entity, got %+v, %v", e, err)
+	}
+	if _, err := c.Lookup("810 305 282"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := c.Lookup("923609017"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+}
@@ -0,0 +1,223 @@
+// Package duplicates finds likely duplicate contacts and purchases.
+package duplicates
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// ContactUsage counts the documents attached to a contact.
+type ContactUsage struct {
+	Invoices  int `json:"invoices"`
+	Sales     int `json:"sales"`
+	Purchases int `json:"purchases"`
+}
+
+func (u ContactUsage) total() int {
+	return u.Invoices + u.Sales + u.Purchases
+}
+
+// ClusterContact is a contact in a duplicate cluster.
+type ClusterContact struct {
+	ContactID          int64  `json:"contactId"`
+	Name               string `json:"name"`
+	OrganizationNumber string `json:"organizationNumber,omitempty"`
+	Email              string `json:"email,omitempty"`
+	PhoneNumber        string `json:"phoneNumber,omitempty"`
+	Customer           bool   `json:"customer"`
+	Supplier           bool   `json:"supplier"`
+	Inactive           bool   `json:"inactive,omitempty"`
+	ContactUsage
+}
+
+// ContactCluster is a group of contacts that share an organization number,
+// normalized name, email domain or phone number, directly or through other
+// contacts in the group.
+type ContactCluster struct {
+	Contacts []ClusterContact `json:"contacts"`
+	// Reasons lists the shared keys, e.g. "organizationNumber 923609016".
+	Reasons []string `json:"reasons"`
+	// Keep is the suggested contact to keep: the one with the most attached
+	// documents, then the active one, then the oldest (lowest ID).
+	Keep int64 `json:"keep"`
+	// Conflicting is set when the contacts have different organization
+	// numbers, so they may be distinct legal entities.
+	Conflicting bool `json:"conflicting,omitempty"`
+}
+
+// freeMailDomains are shared by unrelated people, so they do not indicate a
+// duplicate.
+var freeMailDomains = map[string]bool{
+	"gmail.com": true, "googlemail.com": true, "hotmail.com": true, "hotmail.no": true,
+	"outlook.com": true, "live.com": true, "live.no": true, "msn.com": true,
+	"yahoo.com": true, "yahoo.no": true, "icloud.com": true, "me.com": true,
+	"online.no": true, "start.no": true, "altibox.no": true, "getmail.no": true,
+	"protonmail.com": true, "proton.me": true,
+}
+
+// legalSuffixes are company form words dropped when normalizing names.
+var legalSuffixes = map[string]bool{
+	"as": true, "asa": true, "ans": true, "da": true, "enk": true, "sa": true,
+	"nuf": true, "ba": true, "ks": true, "ltd": true, "limited": true,
+	"ab": true, "aps": true, "oy": true, "gmbh": true, "inc": true, "llc": true,
+}
+
+var nonAlphanumeric = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// NormalizeName lower-cases a name, drops punctuation and company form words
+// such as "AS", and collapses whitespace.
+func NormalizeName(name string) string {
+	words := strings.Fields(nonAlphanumeric.ReplaceAllString(strings.ToLower(name), " "))
+	kept := words[:0]
+	for _, w := range words {
+		if !legalSuffixes[w] {
+			kept = append(kept, w)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// normalizePhone keeps the digits of a phone number without a Norwegian
+// country prefix. Numbers with fewer than 8 digits are ignored.
+func normalizePhone(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	d := b.String()
+	switch {
+	case strings.HasPrefix(d, "0047") && len(d) == 12:
+		d = d[4:]
+	case strings.HasPrefix(d, "47") && len(d) == 10:
+		d = d[2:]
+	}
+	if len(d) < 8 {
+		return ""
+	}
+	return d
+}
+
+func emailDomain(email string) string {
+	i := strings.LastIndex(email, "@")
+	if i < 0 {
+		return ""
+	}
+	domain := strings.ToLower(strings.TrimSpace(email[i+1:]))
+	if domain == "" || freeMailDomains[domain] {
+		return ""
+	}
+	return domain
+}
+
+// FindContactDuplicates clusters contacts that share a key. usage gives the
+// attached documents per contact ID and may be nil.
+func FindContactDuplicates(contacts []fiken.Contact, usage map[int64]ContactUsage) []ContactCluster {
+	parent := make([]int, len(contacts))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	// first maps each key to the first contact that has it; shared marks the
+	// keys found on two or more contacts, in reasonOrder.
+	first := map[string]int{}
+	shared := map[string]bool{}
+	var reasonOrder []string
+	for i, c := range contacts {
+		for _, key := range contactKeys(c) {
+			j, seen := first[key]
+			if !seen {
+				first[key] = i
+				continue
+			}
+			parent[find(i)] = find(j)
+			if !shared[key] {
+				shared[key] = true
+				reasonOrder = append(reasonOrder, key)
+			}
+		}
+	}
+
+	groups := map[int][]int{}
+	for i := range contacts {
+		root := find(i)
+		groups[root] = append(groups[root], i)
+	}
+	var clusters []ContactCluster
+	for _, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+		var cl ContactCluster
+		orgNumbers := map[string]bool{}
+		for _, i := range members {
+			c := contacts[i]
+			cc := ClusterContact{
+				ContactID:          c.ContactID,
+				Name:               c.Name,
+				OrganizationNumber: c.OrganizationNumber,
+				Email:              c.Email,
+				PhoneNumber:        c.PhoneNumber,
+				Customer:           c.Customer,
+				Supplier:           c.Supplier,
+				Inactive:           c.Inactive,
+				ContactUsage:       usage[c.ContactID],
+			}
+			cl.Contacts = append(cl.Contacts, cc)
+			if n := fiken.NormalizeIdentifier(c.OrganizationNumber); n != "" {
+				orgNumbers[n] = true
+			}
+		}
+		cl.Conflicting = len(orgNumbers) > 1
+		root := find(members[0])
+		for _, key := range reasonOrder {
+			if find(first[key]) == root {
+				cl.Reasons = append(cl.Reasons, key)
+			}
+		}
+		sort.Slice(cl.Contacts, func(i, j int) bool { return keepBefore(cl.Contacts[i], cl.Contacts[j]) })
+		cl.Keep = cl.Contacts[0].ContactID
+		clusters = append(clusters, cl)
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Keep < clusters[j].Keep })
+	return clusters
+}
+
+// keepBefore orders the contact to keep first.
+func keepBefore(a, b ClusterContact) bool {
+	if a.total() != b.total() {
+		return a.total() > b.total()
+	}
+	if a.Inactive != b.Inactive {
+		return !a.Inactive
+	}
+	return a.ContactID < b.ContactID
+}
+
+func contactKeys(c fiken.Contact) []string {
+	var keys []string
+	if n := fiken.NormalizeIdentifier(c.OrganizationNumber); n != "" {
+		keys = append(keys, "organizationNumber "+n)
+	}
+	if n := NormalizeName(c.Name); n != "" {
+		keys = append(keys, "name "+n)
+	}
+	if d := emailDomain(c.Email); d != "" {
+		keys = append(keys, "emailDomain "+d)
+	}
+	if p := normalizePhone(c.PhoneNumber); p != "" {
+		keys = append(keys, "phone "+p)
+	}
+	return keys
+}
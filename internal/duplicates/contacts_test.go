@@ -0,0 +1,67 @@
+package duplicates
+
+import (
+	"testing"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := map[string]string{
+		"Kontorrekvisita AS":   "kontorrekvisita",
+		"KONTORREKVISITA A/S":  "kontorrekvisita a s",
+		"Equinor ASA":          "equinor",
+		"  Ola  Nordmann ENK ": "ola nordmann",
+		"Bjørn & Sønn":         "bjørn sønn",
+	}
+	for in, want := range tests {
+		if got := NormalizeName(in); got != want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFindContactDuplicates(t *testing.T) {
+	contacts := []fiken.Contact{
+		{ContactID: 1, Name: "Kontorrekvisita AS", OrganizationNumber: "923609016"},
+		{ContactID: 2, Name: "Kontorrekvisita", Email: "post@kontor.no"},
+		{ContactID: 3, Name: "Kontor Rekvisita Oslo", Email: "faktura@kontor.no", PhoneNumber: "+47 22 33 44 55"},
+		{ContactID: 4, Name: "Ola Nordmann", Email: "ola@gmail.com", PhoneNumber: "22334455"},
+		{ContactID: 5, Name: "Kari Nordmann", Email: "kari@gmail.com"},
+		{ContactID: 6, Name: "Equinor ASA", OrganizationNumber: "923 609 016"},
+	}
+	usage := map[int64]ContactUsage{2: {Invoices: 3}, 6: {Sales: 1}}
+
+	clusters := FindContactDuplicates(contacts, usage)
+
+	if len(clusters) != 1 {
+		t.Fatalf("expected one cluster, got %+v", clusters)
+	}
+	cl := clusters[0]
+	if len(cl.Contacts) != 5 || cl.Keep != 2 || cl.Contacts[1].ContactID != 6 {
+		t.Errorf("unexpected cluster: %+v", cl)
+	}
+	want := []string{"name kontorrekvisita", "emailDomain kontor.no", "phone 22334455", "organizationNumber 923609016"}
+	if len(cl.Reasons) != len(want) {
+		t.Fatalf("reasons = %v, want %v", cl.Reasons, want)
+	}
+	for i := range want {
+		if cl.Reasons[i] != want[i] {
+			t.Errorf("reasons = %v, want %v", cl.Reasons, want)
+		}
+	}
+	if cl.Conflicting {
+		t.Error("expected no conflicting organization numbers")
+	}
+}
+
+func TestFindContactDuplicatesConflicting(t *testing.T) {
+	contacts := []fiken.Contact{
+		{ContactID: 1, Name: "Nordlys AS", OrganizationNumber: "923609016", Inactive: true},
+		{ContactID: 2, Name: "Nordlys AS", OrganizationNumber: "974760673"},
+	}
+	clusters := FindContactDuplicates(contacts, nil)
+	if len(clusters) != 1 || !clusters[0].Conflicting || clusters[0].Keep != 2 {
+		t.Errorf("expected a conflicting cluster keeping the active contact, got %+v", clusters)
+	}
+}
@@ -0,0 +1,85 @@
+package duplicates
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// NewPurchase is a purchase about to be booked.
+type NewPurchase struct {
+	SupplierID int64
+	// Identifier is the supplier's invoice number.
+	Identifier string
+	Date       string
+	Gross      fiken.Amount
+}
+
+// PurchaseMatch is an existing purchase that looks like the new one.
+type PurchaseMatch struct {
+	PurchaseID int64        `json:"purchaseId"`
+	Identifier string       `json:"identifier,omitempty"`
+	Date       string       `json:"date"`
+	Gross      fiken.Amount `json:"gross"`
+	// Reasons lists what matched: "identifier", "amount" and "date".
+	Reasons []string `json:"reasons"`
+}
+
+// FindPurchaseDuplicates returns the existing purchases from the same
+// supplier with the same invoice number, or with the same gross amount and
+// date. Deleted purchases are ignored.
+func FindPurchaseDuplicates(p NewPurchase, existing []fiken.Purchase) []PurchaseMatch {
+	identifier := normalizeInvoiceNumber(p.Identifier)
+	var matches []PurchaseMatch
+	for _, e := range existing {
+		if e.Deleted || e.Supplier == nil || e.Supplier.ContactID != p.SupplierID {
+			continue
+		}
+		gross := e.Gross()
+		var reasons []string
+		if identifier != "" && normalizeInvoiceNumber(e.Identifier) == identifier {
+			reasons = append(reasons, "identifier")
+		}
+		if p.Gross != 0 && gross == p.Gross {
+			reasons = append(reasons, "amount")
+		}
+		if p.Date != "" && e.Date == p.Date {
+			reasons = append(reasons, "date")
+		}
+		if len(reasons) == 0 || (reasons[0] != "identifier" && len(reasons) < 2) {
+			continue
+		}
+		matches = append(matches, PurchaseMatch{
+			PurchaseID: e.PurchaseID,
+			Identifier: e.Identifier,
+			Date:       e.Date,
+			Gross:      gross,
+			Reasons:    reasons,
+		})
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return len(matches[i].Reasons) > len(matches[j].Reasons) })
+	return matches
+}
+
+// normalizeInvoiceNumber drops case, spaces, punctuation and leading zeros, so
+// "INV-0042" and "inv 42" compare equal.
+func normalizeInvoiceNumber(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if (r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z') {
+			b.WriteRune(r)
+		}
+	}
+	n := b.String()
+	// Strip zeros that lead the numeric part, e.g. "INV0042" -> "INV42".
+	i := strings.IndexAny(n, "0123456789")
+	if i < 0 {
+		return n
+	}
+	digits := strings.TrimLeft(n[i:], "0")
+	if digits == "" || digits[0] < '0' || digits[0] > '9' {
+		digits = "0" + digits
+	}
+	return n[:i] + digits
+}
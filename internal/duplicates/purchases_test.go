@@ -0,0 +1,47 @@
+package duplicates
+
+import (
+	"testing"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func TestFindPurchaseDuplicates(t *testing.T) {
+	supplier := &fiken.Contact{ContactID: 7}
+	other := &fiken.Contact{ContactID: 8}
+	lines := []fiken.OrderLine{{NetPrice: 100000, Vat: 25000}}
+	existing := []fiken.Purchase{
+		{PurchaseID: 1, Identifier: "INV-0042", Date: "2026-02-01", Supplier: supplier, Lines: []fiken.OrderLine{{NetPrice: 1000}}},
+		{PurchaseID: 2, Identifier: "77", Date: "2026-03-01", Supplier: supplier, Lines: lines},
+		{PurchaseID: 3, Identifier: "78", Date: "2026-03-02", Supplier: supplier, Lines: lines},
+		{PurchaseID: 4, Identifier: "inv 42", Date: "2026-03-01", Supplier: supplier, Lines: lines, Deleted: true},
+		{PurchaseID: 5, Identifier: "INV42", Date: "2026-03-01", Supplier: other, Lines: lines},
+	}
+
+	matches := FindPurchaseDuplicates(NewPurchase{SupplierID: 7, Identifier: "inv 42", Date: "2026-03-01", Gross: 125000}, existing)
+
+	if len(matches) != 2 {
+		t.Fatalf("expected purchases 1 and 2, got %+v", matches)
+	}
+	if matches[0].PurchaseID != 2 || len(matches[0].Reasons) != 2 || matches[0].Gross != 125000 {
+		t.Errorf("unexpected first match: %+v", matches[0])
+	}
+	if matches[1].PurchaseID != 1 || matches[1].Reasons[0] != "identifier" {
+		t.Errorf("unexpected second match: %+v", matches[1])
+	}
+}
+
+func TestNormalizeInvoiceNumber(t *testing.T) {
+	tests := map[string]string{
+		"INV-0042": "INV42",
+		"inv 42":   "INV42",
+		"000":      "0",
+		"2026/001": "2026001",
+		"A0B":      "A0B",
+	}
+	for in, want := range tests {
+		if got := normalizeInvoiceNumber(in); got != want {
+			t.Errorf("normalizeInvoiceNumber(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+// Package ehf parses EHF (PEPPOL BIS Billing 3.0) UBL invoices and credit
+// notes.
+package ehf
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// Document types.
+const (
+	TypeInvoice    = "invoice"
+	TypeCreditNote = "creditNote"
+)
+
+// Document is a parsed EHF invoice or credit note. Amounts are as stated in
+// the document, in its currency; credit notes keep positive amounts.
+type Document struct {
+	Type        string        `json:"type"`
+	Number      string        `json:"number"`
+	IssueDate   string        `json:"issueDate"`
+	DueDate     string        `json:"dueDate,omitempty"`
+	Currency    string        `json:"currency"`
+	Supplier    Party         `json:"supplier"`
+	KID         string        `json:"kid,omitempty"`
+	BankAccount string        `json:"bankAccount,omitempty"`
+	Lines       []Line        `json:"lines"`
+	VatTotals   []VatSubtotal `json:"vatTotals"`
+	Net         fiken.Amount  `json:"net"`
+	Vat         fiken.Amount  `json:"vat"`
+	Payable     fiken.Amount  `json:"payable"`
+	// Warnings lists what could not be read reliably.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Party is the seller of a document.
+type Party struct {
+	Name               string         `json:"name"`
+	OrganizationNumber string         `json:"organizationNumber,omitempty"`
+	Address            *fiken.Address `json:"address,omitempty"`
+}
+
+// Line is an invoice or credit note line, or a document level allowance
+// (negative) or charge.
+type Line struct {
+	ID          string       `json:"id,omitempty"`
+	Description string       `json:"description"`
+	Quantity    string       `json:"quantity,omitempty"`
+	Net         fiken.Amount `json:"net"`
+	VatCategory string       `json:"vatCategory"`
+	// VatRate is the VAT percentage in basis points (2500 = 25 %).
+	VatRate int64 `json:"vatRate"`
+}
+
+// VatSubtotal is the VAT stated for one category and rate.
+type VatSubtotal struct {
+	Category string       `json:"category"`
+	VatRate  int64        `json:"vatRate"`
+	Taxable  fiken.Amount `json:"taxable"`
+	Vat      fiken.Amount `json:"vat"`
+}
+
+// ublID is an identifier with its scheme, e.g. 0192 for Norwegian
+// organization numbers and 0088 for GLNs.
+type ublID struct {
+	Value  string `xml:",chardata"`
+	Scheme string `xml:"schemeID,attr"`
+}
+
+type ublParty struct {
+	EndpointID ublID  `xml:"Party>EndpointID"`
+	Name       string `xml:"Party>PartyName>Name"`
+	Address    struct {
+		Street  string `xml:"StreetName"`
+		Street2 string `xml:"AdditionalStreetName"`
+		City    string `xml:"CityName"`
+		Postal  string `xml:"PostalZone"`
+		Country string `xml:"Country>IdentificationCode"`
+	} `xml:"Party>PostalAddress"`
+	TaxCompanyID     string `xml:"Party>PartyTaxScheme>CompanyID"`
+	RegistrationName string `xml:"Party>PartyLegalEntity>RegistrationName"`
+	LegalCompanyID   ublID  `xml:"Party>PartyLegalEntity>CompanyID"`
+}
+
+type ublTaxCategory struct {
+	ID      string `xml:"ID"`
+	Percent string `xml:"Percent"`
+}
+
+type ublLine struct {
+	ID                  string         `xml:"ID"`
+	InvoicedQuantity    string         `xml:"InvoicedQuantity"`
+	CreditedQuantity    string         `xml:"CreditedQuantity"`
+	LineExtensionAmount string         `xml:"LineExtensionAmount"`
+	Name                string         `xml:"Item>Name"`
+	Description         string         `xml:"Item>Description"`
+	TaxCategory         ublTaxCategory `xml:"Item>ClassifiedTaxCategory"`
+}
+
+type ublDocument struct {
+	XMLName      xml.Name
+	ID           string   `xml:"ID"`
+	IssueDate    string   `xml:"IssueDate"`
+	DueDate      string   `xml:"DueDate"`
+	Currency     string   `xml:"DocumentCurrencyCode"`
+	Supplier     ublParty `xml:"AccountingSupplierParty"`
+	PaymentMeans []struct {
+		DueDate   string `xml:"PaymentDueDate"`
+		PaymentID string `xml:"PaymentID"`
+		Account   string `xml:"PayeeFinancialAccount>ID"`
+	} `xml:"PaymentMeans"`
+	AllowanceCharges []struct {
+		ChargeIndicator bool           `xml:"ChargeIndicator"`
+		Reason          string         `xml:"AllowanceChargeReason"`
+		Amount          string         `xml:"Amount"`
+		TaxCategory     ublTaxCategory `xml:"TaxCategory"`
+	} `xml:"AllowanceCharge"`
+	TaxTotals []struct {
+		TaxAmount string `xml:"TaxAmount"`
+		Subtotals []struct {
+			Taxable     string         `xml:"TaxableAmount"`
+			Tax         string         `xml:"TaxAmount"`
+			TaxCategory ublTaxCategory `xml:"TaxCategory"`
+		} `xml:"TaxSubtotal"`
+	} `xml:"TaxTotal"`
+	TaxExclusiveAmount string    `xml:"LegalMonetaryTotal>TaxExclusiveAmount"`
+	PayableAmount      string    `xml:"LegalMonetaryTotal>PayableAmount"`
+	InvoiceLines       []ublLine `xml:"InvoiceLine"`
+	CreditNoteLines    []ublLine `xml:"CreditNoteLine"`
+}
+
+var (
+	orgNrPattern     = regexp.MustCompile(`^[0-9]{9}$`)
+	vatNumberPattern = regexp.MustCompile(`^NO([0-9]{9})MVA$`)
+)
+
+// Parse parses a PEPPOL BIS Billing 3.0 invoice or credit note.
+func Parse(data []byte) (Document, error) {
+	var u ublDocument
+	if err := xml.Unmarshal(data, &u); err != nil {
+		return Document{}, fmt.Errorf("parsing EHF: %w", err)
+	}
+	d := Document{
+		Number:    strings.TrimSpace(u.ID),
+		IssueDate: strings.TrimSpace(u.IssueDate),
+		DueDate:   strings.TrimSpace(u.DueDate),
+		Currency:  strings.TrimSpace(u.Currency),
+		Lines:     []Line{},
+		VatTotals: []VatSubtotal{},
+	}
+	lines := u.InvoiceLines
+	switch u.XMLName.Local {
+	case "Invoice":
+		d.Type = TypeInvoice
+	case "CreditNote":
+		d.Type = TypeCreditNote
+		lines = u.CreditNoteLines
+	default:
+		return Document{}, fmt.Errorf("parsing EHF: expected an Invoice or CreditNote document, got %s", u.XMLName.Local)
+	}
+
+	d.Supplier = Party{Name: strings.TrimSpace(u.Supplier.RegistrationName)}
+	if d.Supplier.Name == "" {
+		d.Supplier.Name = strings.TrimSpace(u.Supplier.Name)
+	}
+	var ids []string
+	for _, id := range []ublID{u.Supplier.LegalCompanyID, u.Supplier.EndpointID, {Value: u.Supplier.TaxCompanyID}} {
+		if strings.TrimSpace(id.Value) == "" {
+			continue
+		}
+		ids = append(ids, strings.TrimSpace(id.Value))
+		if orgNr, ok := organizationNumber(id); ok {
+			d.Supplier.OrganizationNumber = orgNr
+			break
+		}
+	}
+	if d.Supplier.OrganizationNumber == "" && len(ids) > 0 {
+		d.Warnings = append(d.Warnings, fmt.Sprintf("the supplier identifiers (%s) contain no valid Norwegian organization number",
+			strings.Join(ids, ", ")))
+	}
+	if a := u.Supplier.Address; a.City != "" || a.Street != "" {
+		d.Supplier.Address = &fiken.Address{
+			StreetAddress:      a.Street,
+			StreetAddressLine2: a.Street2,
+			City:               a.City,
+			PostCode:           a.Postal,
+			Country:            a.Country,
+		}
+	}
+
+	for _, pm := range u.PaymentMeans {
+		if d.KID == "" {
+			d.KID = strings.TrimSpace(pm.PaymentID)
+		}
+		if d.BankAccount == "" {
+			d.BankAccount = strings.TrimSpace(pm.Account)
+		}
+		if d.DueDate == "" {
+			d.DueDate = strings.TrimSpace(pm.DueDate)
+		}
+	}
+
+	var err error
+	for _, l := range lines {
+		line := Line{
+			ID:          strings.TrimSpace(l.ID),
+			Description: joinText(l.Name, l.Description),
+			Quantity:    strings.TrimSpace(l.InvoicedQuantity + l.CreditedQuantity),
+			VatCategory: strings.TrimSpace(l.TaxCategory.ID),
+		}
+		if line.Net, err = amount(l.LineExtensionAmount, "line "+line.ID); err != nil {
+			return Document{}, err
+		}
+		if line.VatRate, err = rate(l.TaxCategory.Percent); err != nil {
+			return Document{}, err
+		}
+		d.Lines = append(d.Lines, line)
+	}
+	for _, ac := range u.AllowanceCharges {
+		line := Line{Description: strings.TrimSpace(ac.Reason), VatCategory: strings.TrimSpace(ac.TaxCategory.ID)}
+		if line.Description == "" {
+			line.Description = "Rabatt"
+			if ac.ChargeIndicator {
+				line.Description = "Gebyr"
+			}
+		}
+		if line.Net, err = amount(ac.Amount, "allowance/charge"); err != nil {
+			return Document{}, err
+		}
+		if !ac.ChargeIndicator {
+			line.Net = -line.Net
+		}
+		if line.VatRate, err = rate(ac.TaxCategory.Percent); err != nil {
+			return Document{}, err
+		}
+		d.Lines = append(d.Lines, line)
+	}
+
+	// A document in foreign currency has a second TaxTotal, without
+	// subtotals, in the accounting currency; use the one with subtotals.
+	for i, tt := range u.TaxTotals {
+		if i > 0 && len(tt.Subtotals) == 0 {
+			continue
+		}
+		if d.Vat, err = amount(tt.TaxAmount, "tax total"); err != nil {
+			return Document{}, err
+		}
+		d.VatTotals = d.VatTotals[:0]
+		for _, st := range tt.Subtotals {
+			sub := VatSubtotal{Category: strings.TrimSpace(st.TaxCategory.ID)}
+			if sub.Taxable, err = amount(st.Taxable, "tax subtotal"); err != nil {
+				return Document{}, err
+			}
+			if sub.Vat, err = amount(st.Tax, "tax subtotal"); err != nil {
+				return Document{}, err
+			}
+			if sub.VatRate, err = rate(st.TaxCategory.Percent); err != nil {
+				return Document{}, err
+			}
+			d.VatTotals = append(d.VatTotals, sub)
+		}
+		if len(tt.Subtotals) > 0 {
+			break
+		}
+	}
+	if d.Net, err = amount(u.TaxExclusiveAmount, "tax exclusive amount"); err != nil {
+		return Document{}, err
+	}
+	if d.Payable, err = amount(u.PayableAmount, "payable amount"); err != nil {
+		return Document{}, err
+	}
+	if len(d.Lines) == 0 {
+		return Document{}, fmt.Errorf("parsing EHF: the document has no lines")
+	}
+	return d, nil
+}
+
+// PurchaseVatType maps a UNCL5305 VAT category and rate (in basis points) to
+// Fiken's vatType for purchase lines. ok is false for combinations that need
+// a manual decision, such as reverse charge (AE).
+func PurchaseVatType(category string, rate int64) (vatType string, ok bool) {
+	switch category {
+	case "S":
+		switch rate {
+		case 2500:
+			return "HIGH", true
+		case 1500:
+			return "MEDIUM", true
+		case 1200:
+			return "LOW", true
+		case 1111:
+			return "RAW_FISH", true
+		}
+	case "Z", "E", "O", "K", "G":
+		return "NONE", true
+	}
+	return "", false
+}
+
+func amount(s, what string) (fiken.Amount, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	a, err := fiken.ParseAmount(s)
+	if err != nil {
+		return 0, fmt.Errorf("parsing EHF %s: %w", what, err)
+	}
+	return a, nil
+}
+
+// rate parses a VAT percentage such as "25" or "11.11" into basis points.
+func rate(s string) (int64, error) {
+	a, err := amount(s, "VAT percent")
+	return int64(a), err
+}
+
+func joinText(parts ...string) string {
+	var kept []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, " – ")
+}
+
+// organizationNumber returns the Norwegian organization number in a party
+// identifier: one with scheme 0192, a VAT number such as NO987654321MVA, or
+// a bare nine digit number without a scheme. Other schemes, such as GLNs
+// (0088) and foreign VAT numbers, are not organization numbers. The number
+// must pass the MOD11 check.
+func organizationNumber(id ublID) (string, bool) {
+	v := fiken.NormalizeIdentifier(id.Value)
+	scheme := strings.TrimSpace(id.Scheme)
+	var orgNr string
+	switch {
+	case scheme == "0192":
+		orgNr = v
+	case vatNumberPattern.MatchString(v):
+		orgNr = vatNumberPattern.FindStringSubmatch(v)[1]
+	case scheme == "" && orgNrPattern.MatchString(v):
+		orgNr = v
+	default:
+		return "", false
+	}
+	if fiken.ValidateOrganizationNumber(orgNr) != nil {
+		return "", false
+	}
+	return orgNr, true
+}
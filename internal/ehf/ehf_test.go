@@ -0,0 +1,204 @@
+package ehf
+
+import (
+	"strings"
+	"testing"
+)
+
+const invoiceSample = `<?xml version="1.0" encoding="UTF-8"?>
+<Invoice xmlns="urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+  xmlns:cac="urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+  xmlns:cbc="urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2">
+  <cbc:CustomizationID>urn:cen.eu:en16931:2017#compliant#urn:fdc:peppol.eu:2017:poacc:billing:3.0</cbc:CustomizationID>
+  <cbc:ID>INV-1001</cbc:ID>
+  <cbc:IssueDate>2026-03-01</cbc:IssueDate>
+  <cbc:DueDate>2026-03-15</cbc:DueDate>
+  <cbc:InvoiceTypeCode>380</cbc:InvoiceTypeCode>
+  <cbc:DocumentCurrencyCode>NOK</cbc:DocumentCurrencyCode>
+  <cac:AccountingSupplierParty>
+    <cac:Party>
+      <cbc:EndpointID schemeID="0192">986180516</cbc:EndpointID>
+      <cac:PartyName><cbc:Name>Kontor</cbc:Name></cac:PartyName>
+      <cac:PostalAddress>
+        <cbc:StreetName>Storgata 1</cbc:StreetName>
+        <cbc:CityName>Oslo</cbc:CityName>
+        <cbc:PostalZone>0155</cbc:PostalZone>
+        <cac:Country><cbc:IdentificationCode>NO</cbc:IdentificationCode></cac:Country>
+      </cac:PostalAddress>
+      <cac:PartyTaxScheme><cbc:CompanyID>NO986180516MVA</cbc:CompanyID><cac:TaxScheme><cbc:ID>VAT</cbc:ID></cac:TaxScheme></cac:PartyTaxScheme>
+      <cac:PartyLegalEntity><cbc:RegistrationName>Kontorrekvisita AS</cbc:RegistrationName></cac:PartyLegalEntity>
+    </cac:Party>
+  </cac:AccountingSupplierParty>
+  <cac:AccountingCustomerParty>
+    <cac:Party><cbc:EndpointID schemeID="0192">999999999</cbc:EndpointID></cac:Party>
+  </cac:AccountingCustomerParty>
+  <cac:PaymentMeans>
+    <cbc:PaymentMeansCode>30</cbc:PaymentMeansCode>
+    <cbc:PaymentID>0000123455</cbc:PaymentID>
+    <cac:PayeeFinancialAccount><cbc:ID>12345678903</cbc:ID></cac:PayeeFinancialAccount>
+  </cac:PaymentMeans>
+  <cac:AllowanceCharge>
+    <cbc:ChargeIndicator>true</cbc:ChargeIndicator>
+    <cbc:AllowanceChargeReason>Frakt</cbc:AllowanceChargeReason>
+    <cbc:Amount currencyID="NOK">100.00</cbc:Amount>
+    <cac:TaxCategory><cbc:ID>S</cbc:ID><cbc:Percent>25</cbc:Percent></cac:TaxCategory>
+  </cac:AllowanceCharge>
+  <cac:TaxTotal>
+    <cbc:TaxAmount currencyID="NOK">275.00</cbc:TaxAmount>
+    <cac:TaxSubtotal>
+      <cbc:TaxableAmount currencyID="NOK">1100.00</cbc:TaxableAmount>
+      <cbc:TaxAmount currencyID="NOK">275.00</cbc:TaxAmount>
+      <cac:TaxCategory><cbc:ID>S</cbc:ID><cbc:Percent>25</cbc:Percent></cac:TaxCategory>
+    </cac:TaxSubtotal>
+    <cac:TaxSubtotal>
+      <cbc:TaxableAmount currencyID="NOK">50.00</cbc:TaxableAmount>
+      <cbc:TaxAmount currencyID="NOK">0.00</cbc:TaxAmount>
+      <cac:TaxCategory><cbc:ID>Z</cbc:ID><cbc:Percent>0</cbc:Percent></cac:TaxCategory>
+    </cac:TaxSubtotal>
+  </cac:TaxTotal>
+  <cac:LegalMonetaryTotal>
+    <cbc:LineExtensionAmount currencyID="NOK">1050.00</cbc:LineExtensionAmount>
+    <cbc:TaxExclusiveAmount currencyID="NOK">1150.00</cbc:TaxExclusiveAmount>
+    <cbc:TaxInclusiveAmount currencyID="NOK">1425.00</cbc:TaxInclusiveAmount>
+    <cbc:ChargeTotalAmount currencyID="NOK">100.00</cbc:ChargeTotalAmount>
+    <cbc:PayableAmount currencyID="NOK">1425.00</cbc:PayableAmount>
+  </cac:LegalMonetaryTotal>
+  <cac:InvoiceLine>
+    <cbc:ID>1</cbc:ID>
+    <cbc:InvoicedQuantity unitCode="EA">10</cbc:InvoicedQuantity>
+    <cbc:LineExtensionAmount currencyID="NOK">1000.00</cbc:LineExtensionAmount>
+    <cac:Item>
+      <cbc:Name>Printerpapir</cbc:Name>
+      <cac:ClassifiedTaxCategory><cbc:ID>S</cbc:ID><cbc:Percent>25</cbc:Percent><cac:TaxScheme><cbc:ID>VAT</cbc:ID></cac:TaxScheme></cac:ClassifiedTaxCategory>
+    </cac:Item>
+    <cac:Price><cbc:PriceAmount currencyID="NOK">100.00</cbc:PriceAmount></cac:Price>
+  </cac:InvoiceLine>
+  <cac:InvoiceLine>
+    <cbc:ID>2</cbc:ID>
+    <cbc:InvoicedQuantity unitCode="EA">1</cbc:InvoicedQuantity>
+    <cbc:LineExtensionAmount currencyID="NOK">50.00</cbc:LineExtensionAmount>
+    <cac:Item>
+      <cbc:Name>Avis</cbc:Name>
+      <cac:ClassifiedTaxCategory><cbc:ID>Z</cbc:ID><cbc:Percent>0</cbc:Percent><cac:TaxScheme><cbc:ID>VAT</cbc:ID></cac:TaxScheme></cac:ClassifiedTaxCategory>
+    </cac:Item>
+    <cac:Price><cbc:PriceAmount currencyID="NOK">50.00</cbc:PriceAmount></cac:Price>
+  </cac:InvoiceLine>
+</Invoice>`
+
+const creditNoteSample = `<?xml version="1.0" encoding="UTF-8"?>
+<CreditNote xmlns="urn:oasis:names:specification:ubl:schema:xsd:CreditNote-2"
+  xmlns:cac="urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+  xmlns:cbc="urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2">
+  <cbc:ID>CN-7</cbc:ID>
+  <cbc:IssueDate>2026-03-10</cbc:IssueDate>
+  <cbc:DocumentCurrencyCode>NOK</cbc:DocumentCurrencyCode>
+  <cac:AccountingSupplierParty><cac:Party>
+    <cac:PartyLegalEntity><cbc:RegistrationName>Kontorrekvisita AS</cbc:RegistrationName><cbc:CompanyID>986 180 516</cbc:CompanyID></cac:PartyLegalEntity>
+  </cac:Party></cac:AccountingSupplierParty>
+  <cac:TaxTotal><cbc:TaxAmount currencyID="NOK">25.00</cbc:TaxAmount></cac:TaxTotal>
+  <cac:LegalMonetaryTotal>
+    <cbc:TaxExclusiveAmount currencyID="NOK">100.00</cbc:TaxExclusiveAmount>
+    <cbc:PayableAmount currencyID="NOK">125.00</cbc:PayableAmount>
+  </cac:LegalMonetaryTotal>
+  <cac:CreditNoteLine>
+    <cbc:ID>1</cbc:ID>
+    <cbc:CreditedQuantity unitCode="EA">1</cbc:CreditedQuantity>
+    <cbc:LineExtensionAmount currencyID="NOK">100.00</cbc:LineExtensionAmount>
+    <cac:Item><cbc:Name>Retur</cbc:Name><cac:ClassifiedTaxCategory><cbc:ID>S</cbc:ID><cbc:Percent>25</cbc:Percent></cac:ClassifiedTaxCategory></cac:Item>
+  </cac:CreditNoteLine>
+</CreditNote>`
+
+func TestParseInvoice(t *testing.T) {
+	d, err := Parse([]byte(invoiceSample))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Type != TypeInvoice || d.Number != "INV-1001" || d.IssueDate != "2026-03-01" || d.DueDate != "2026-03-15" || d.Currency != "NOK" {
+		t.Errorf("unexpected header: %+v", d)
+	}
+	if d.Supplier.Name != "Kontorrekvisita AS" || d.Supplier.OrganizationNumber != "986180516" || d.Supplier.Address.City != "Oslo" {
+		t.Errorf("unexpected supplier: %+v", d.Supplier)
+	}
+	if d.KID != "0000123455" || d.BankAccount != "12345678903" {
+		t.Errorf("unexpected payment details: KID %q, account %q", d.KID, d.BankAccount)
+	}
+	if len(d.Lines) != 3 {
+		t.Fatalf("expected 2 lines and 1 charge, got %+v", d.Lines)
+	}
+	if l := d.Lines[0]; l.Net != 100000 || l.VatCategory != "S" || l.VatRate != 2500 || l.Quantity != "10" {
+		t.Errorf("unexpected first line: %+v", l)
+	}
+	if l := d.Lines[2]; l.Description != "Frakt" || l.Net != 10000 {
+		t.Errorf("unexpected charge line: %+v", l)
+	}
+	if d.Net != 115000 || d.Vat != 27500 || d.Payable != 142500 || len(d.VatTotals) != 2 {
+		t.Errorf("unexpected totals: net %d, vat %d, payable %d, %d subtotals", d.Net, d.Vat, d.Payable, len(d.VatTotals))
+	}
+}
+
+func TestParseCreditNote(t *testing.T) {
+	d, err := Parse([]byte(creditNoteSample))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Type != TypeCreditNote || d.Supplier.OrganizationNumber != "986180516" || len(d.Lines) != 1 || d.Lines[0].Net != 10000 || d.Vat != 2500 {
+		t.Errorf("unexpected credit note: %+v", d)
+	}
+}
+
+func TestParseSupplierOrganizationNumber(t *testing.T) {
+	endpoint := `<cbc:EndpointID schemeID="0192">986180516</cbc:EndpointID>`
+	tax := `<cbc:CompanyID>NO986180516MVA</cbc:CompanyID>`
+	tests := []struct {
+		name, endpoint, tax, want string
+	}{
+		{"organization number scheme", endpoint, "<cbc:CompanyID></cbc:CompanyID>", "986180516"},
+		{"Norwegian VAT number", `<cbc:EndpointID schemeID="0088">7080000000000</cbc:EndpointID>`, tax, "986180516"},
+		{"GLN", `<cbc:EndpointID schemeID="0088">7080000000000</cbc:EndpointID>`, "<cbc:CompanyID></cbc:CompanyID>", ""},
+		{"foreign VAT number", `<cbc:EndpointID schemeID="0007">5566778899</cbc:EndpointID>`, "<cbc:CompanyID>SE556677889901</cbc:CompanyID>", ""},
+		{"failing MOD11", `<cbc:EndpointID schemeID="0192">987654321</cbc:EndpointID>`, "<cbc:CompanyID></cbc:CompanyID>", ""},
+	}
+	for _, tt := range tests {
+		doc := strings.Replace(strings.Replace(invoiceSample, endpoint, tt.endpoint, 1), tax, tt.tax, 1)
+		d, err := Parse([]byte(doc))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if d.Supplier.OrganizationNumber != tt.want {
+			t.Errorf("%s: organization number %q, want %q", tt.name, d.Supplier.OrganizationNumber, tt.want)
+		}
+		if (tt.want == "") != (len(d.Warnings) == 1) {
+			t.Errorf("%s: unexpected warnings %v", tt.name, d.Warnings)
+		}
+	}
+}
+
+func TestParseRejectsOtherDocuments(t *testing.T) {
+	if _, err := Parse([]byte(`<Order><ID>1</ID></Order>`)); err == nil {
+		t.Error("expected an error for a non-invoice document")
+	}
+}
+
+func TestPurchaseVatType(t *testing.T) {
+	tests := []struct {
+		category string
+		rate     int64
+		want     string
+		ok       bool
+	}{
+		{"S", 2500, "HIGH", true},
+		{"S", 1500, "MEDIUM", true},
+		{"S", 1200, "LOW", true},
+		{"S", 1111, "RAW_FISH", true},
+		{"Z", 0, "NONE", true},
+		{"E", 0, "NONE", true},
+		{"AE", 0, "", false},
+		{"S", 800, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := PurchaseVatType(tt.category, tt.rate)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("PurchaseVatType(%q, %d) = %q, %v; want %q, %v", tt.category, tt.rate, got, ok, tt.want, tt.ok)
+		}
+	}
+}
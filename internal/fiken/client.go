@@ -2,8 +2,10 @@ package fiken
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -11,6 +13,20 @@ import (
 
 const baseURL = "https://api.fiken.no/api/v2"
 
+// maxPageSize is the largest page size the Fiken API accepts.
+const maxPageSize = 100
+
+// APIError is returned by the decoding helpers when Fiken responds with an
+// error status. Its message matches the format the tools report to the model.
+type APIError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error %d: %s", e.StatusCode, string(e.Body))
+}
+
 // Client is an HTTP client for the Fiken API.
 type Client struct {
 	apiKey     string
@@ -28,9 +44,22 @@ func NewClient(apiKey string) *Client {
 // Do executes an HTTP request against the Fiken API.
 // Returns (body, statusCode, error).
 func (c *Client) Do(method, path string, body []byte, queryParams map[string]string) ([]byte, int, error) {
+	var contentType string
+	if method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch {
+		contentType = "application/json"
+	}
+	return c.do(method, path, body, queryParams, contentType)
+}
+
+func (c *Client) do(method, path string, body []byte, queryParams map[string]string, contentType string) ([]byte, int, error) {
+	respBody, status, _, err := c.doWithHeader(method, path, body, queryParams, contentType)
+	return respBody, status, err
+}
+
+func (c *Client) doWithHeader(method, path string, body []byte, queryParams map[string]string, contentType string) ([]byte, int, http.Header, error) {
 	u, err := url.Parse(baseURL + path)
 	if err != nil {
-		return nil, 0, fmt.Errorf("invalid URL: %w", err)
+		return nil, 0, nil, fmt.Errorf("invalid URL: %w", err)
 	}
 
 	if len(queryParams) > 0 {
@@ -48,23 +77,23 @@ func (c *Client) Do(method, path string, body []byte, queryParams map[string]str
 
 	req, err := http.NewRequest(method, u.String(), bodyReader)
 	if err != nil {
-		return nil, 0, fmt.Errorf("creating request: %w", err)
+		return nil, 0, nil, fmt.Errorf("creating request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	if method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch {
-		req.Header.Set("Content-Type", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, 0, fmt.Errorf("executing request: %w", err)
+		return nil, 0, nil, fmt.Errorf("executing request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("reading response body: %w", err)
+		return nil, resp.StatusCode, resp.Header, fmt.Errorf("reading response body: %w", err)
 	}
 
 	// Only convert monetary fields in successful responses; error responses contain
@@ -73,7 +102,7 @@ func (c *Client) Do(method, path string, body []byte, queryParams map[string]str
 		respBody = ConvertMoneyFieldsFromOre(respBody)
 	}
 
-	return respBody, resp.StatusCode, nil
+	return respBody, resp.StatusCode, resp.Header, nil
 }
 
 // Get performs a GET request.
@@ -89,6 +118,20 @@ func (c *Client) Post(path string, body []byte) ([]byte, int, error) {
 	return c.Do(http.MethodPost, path, body, nil)
 }
 
+// PostLocation performs a POST request like Post and also returns the
+// Location header, which Fiken sets to the created resource.
+func (c *Client) PostLocation(path string, body []byte) ([]byte, int, string, error) {
+	if body != nil {
+		body = ConvertMoneyFieldsToOre(body)
+	}
+	respBody, status, header, err := c.doWithHeader(http.MethodPost, path, body, nil, "application/json")
+	var location string
+	if header != nil {
+		location = header.Get("Location")
+	}
+	return respBody, status, location, err
+}
+
 // Put performs a PUT request.
 func (c *Client) Put(path string, body []byte) ([]byte, int, error) {
 	if body != nil {
@@ -97,11 +140,91 @@ func (c *Client) Put(path string, body []byte) ([]byte, int, error) {
 	return c.Do(http.MethodPut, path, body, nil)
 }
 
+// Patch performs a PATCH request. Fiken uses PATCH with query parameters for
+// actions such as deleting a booked sale, so both are accepted.
+func (c *Client) Patch(path string, body []byte, queryParams map[string]string) ([]byte, int, error) {
+	if body != nil {
+		body = ConvertMoneyFieldsToOre(body)
+	}
+	return c.Do(http.MethodPatch, path, body, queryParams)
+}
+
+// PostMultipart performs a multipart/form-data POST request, as used by the
+// attachment endpoints. The file content is sent in a part named "file".
+func (c *Client) PostMultipart(path string, fields map[string]string, filename string, content []byte) ([]byte, int, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for k, v := range fields {
+		if err := w.WriteField(k, v); err != nil {
+			return nil, 0, fmt.Errorf("writing form field %s: %w", k, err)
+		}
+	}
+	part, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, 0, fmt.Errorf("creating form file: %w", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		return nil, 0, fmt.Errorf("writing form file: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, 0, fmt.Errorf("closing multipart body: %w", err)
+	}
+	return c.do(http.MethodPost, path, buf.Bytes(), nil, w.FormDataContentType())
+}
+
 // Delete performs a DELETE request.
 func (c *Client) Delete(path string) ([]byte, int, error) {
 	return c.Do(http.MethodDelete, path, nil, nil)
 }
 
+// GetJSON performs a GET request and decodes the (money-converted) response into v.
+func (c *Client) GetJSON(path string, queryParams map[string]string, v interface{}) error {
+	body, status, err := c.Get(path, queryParams)
+	if err != nil {
+		return err
+	}
+	if status >= 400 {
+		return &APIError{StatusCode: status, Body: body}
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return nil
+}
+
+// GetAll fetches every page of a list endpoint and decodes the combined items
+// into v, which must be a pointer to a slice. Any page or pageSize in
+// queryParams is overridden.
+func (c *Client) GetAll(path string, queryParams map[string]string, v interface{}) error {
+	params := make(map[string]string, len(queryParams)+2)
+	for k, val := range queryParams {
+		params[k] = val
+	}
+	params["pageSize"] = strconv.Itoa(maxPageSize)
+
+	var items []json.RawMessage
+	for page := 0; ; page++ {
+		params["page"] = strconv.Itoa(page)
+		var pageItems []json.RawMessage
+		if err := c.GetJSON(path, params, &pageItems); err != nil {
+			return err
+		}
+		items = append(items, pageItems...)
+		if len(pageItems) < maxPageSize {
+			break
+		}
+	}
+
+	combined, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(combined, v); err != nil {
+		return fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return nil
+}
+
 // BuildQueryParams builds query params from key-value pairs.
 // Nil values and empty strings are omitted.
 func BuildQueryParams(pairs ...interface{}) map[string]string {
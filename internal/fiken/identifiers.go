@@ -0,0 +1,104 @@
+package fiken
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// NormalizeIdentifier removes the spaces and dots people write in
+// organization numbers, account numbers and IBANs, and upper-cases letters.
+func NormalizeIdentifier(s string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", ".", "", "\u00a0", "").Replace(s))
+}
+
+// ValidateOrganizationNumber checks a Norwegian organization number: nine
+// digits, the last a MOD11 check digit with weights 3, 2, 7, 6, 5, 4, 3, 2.
+func ValidateOrganizationNumber(s string) error {
+	n := NormalizeIdentifier(s)
+	if len(n) != 9 || !allDigits(n) {
+		return fmt.Errorf("organization number %q must be 9 digits", s)
+	}
+	if !mod11Valid(n, []int{3, 2, 7, 6, 5, 4, 3, 2}) {
+		return fmt.Errorf("organization number %q has an invalid check digit", s)
+	}
+	return nil
+}
+
+// ValidateBankAccountNumber checks a Norwegian bank account number: eleven
+// digits, the last a MOD11 check digit with weights 5, 4, 3, 2, 7, 6, 5, 4,
+// 3, 2.
+func ValidateBankAccountNumber(s string) error {
+	n := NormalizeIdentifier(s)
+	if len(n) != 11 || !allDigits(n) {
+		return fmt.Errorf("bank account number %q must be 11 digits", s)
+	}
+	if !mod11Valid(n, []int{5, 4, 3, 2, 7, 6, 5, 4, 3, 2}) {
+		return fmt.Errorf("bank account number %q has an invalid check digit", s)
+	}
+	return nil
+}
+
+// ValidateIBAN checks the format and mod 97 checksum of an IBAN. A Norwegian
+// IBAN must also be 15 characters and hold a valid bank account number.
+func ValidateIBAN(s string) error {
+	n := NormalizeIdentifier(s)
+	if len(n) < 15 || len(n) > 34 || !isUpperLetters(n[:2]) || !allDigits(n[2:4]) || !isAlphanumeric(n[4:]) {
+		return fmt.Errorf("IBAN %q must be a country code, two check digits and up to 30 letters or digits", s)
+	}
+	if n[:2] == "NO" && len(n) != 15 {
+		return fmt.Errorf("IBAN %q is Norwegian and must be 15 characters", s)
+	}
+	var digits strings.Builder
+	for _, r := range n[4:] + n[:4] {
+		if r >= 'A' && r <= 'Z' {
+			fmt.Fprintf(&digits, "%d", r-'A'+10)
+		} else {
+			digits.WriteRune(r)
+		}
+	}
+	v, _ := new(big.Int).SetString(digits.String(), 10)
+	if new(big.Int).Mod(v, big.NewInt(97)).Int64() != 1 {
+		return fmt.Errorf("IBAN %q has invalid check digits", s)
+	}
+	if n[:2] == "NO" {
+		if err := ValidateBankAccountNumber(n[4:]); err != nil {
+			return fmt.Errorf("IBAN %q does not hold a valid Norwegian account number", s)
+		}
+	}
+	return nil
+}
+
+// ValidateBIC checks the format of a BIC (SWIFT code): four letters for the
+// bank, two for the country, two letters or digits for the location and an
+// optional three for the branch.
+func ValidateBIC(s string) error {
+	n := NormalizeIdentifier(s)
+	if (len(n) != 8 && len(n) != 11) || !isUpperLetters(n[:6]) || !isAlphanumeric(n[6:]) {
+		return fmt.Errorf("BIC %q must be 8 or 11 characters: bank code, country code, location and optional branch", s)
+	}
+	return nil
+}
+
+// mod11Valid reports whether the last digit of n is the MOD11 check digit of
+// the others under weights. A remainder giving check digit 10 is never valid.
+func mod11Valid(n string, weights []int) bool {
+	sum := 0
+	for i, w := range weights {
+		sum += int(n[i]-'0') * w
+	}
+	check := (11 - sum%11) % 11
+	return check != 10 && int(n[len(n)-1]-'0') == check
+}
+
+func allDigits(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+func isUpperLetters(s string) bool {
+	return s != "" && strings.Trim(s, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") == ""
+}
+
+func isAlphanumeric(s string) bool {
+	return s != "" && strings.Trim(s, "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789") == ""
+}
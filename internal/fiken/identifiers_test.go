@@ -0,0 +1,55 @@
+package fiken
+
+import "testing"
+
+func TestValidateOrganizationNumber(t *testing.T) {
+	for _, s := range []string{"923609016", "923 609 016"} {
+		if err := ValidateOrganizationNumber(s); err != nil {
+			t.Errorf("expected %q to be valid: %v", s, err)
+		}
+	}
+	for _, s := range []string{"923609017", "92360901", "9236090166", "92360901a"} {
+		if err := ValidateOrganizationNumber(s); err == nil {
+			t.Errorf("expected %q to be invalid", s)
+		}
+	}
+}
+
+func TestValidateBankAccountNumber(t *testing.T) {
+	for _, s := range []string{"12345678903", "1234.56.78903", "8601 11 17947"} {
+		if err := ValidateBankAccountNumber(s); err != nil {
+			t.Errorf("expected %q to be valid: %v", s, err)
+		}
+	}
+	for _, s := range []string{"12345678904", "1234567890", "NO9386011117947"} {
+		if err := ValidateBankAccountNumber(s); err == nil {
+			t.Errorf("expected %q to be invalid", s)
+		}
+	}
+}
+
+func TestValidateIBAN(t *testing.T) {
+	for _, s := range []string{"NO93 8601 1117 947", "GB82WEST12345698765432", "de89370400440532013000"} {
+		if err := ValidateIBAN(s); err != nil {
+			t.Errorf("expected %q to be valid: %v", s, err)
+		}
+	}
+	for _, s := range []string{"NO94 8601 1117 947", "GB82WEST1234569876543", "NO938601111794", "12345678903"} {
+		if err := ValidateIBAN(s); err == nil {
+			t.Errorf("expected %q to be invalid", s)
+		}
+	}
+}
+
+func TestValidateBIC(t *testing.T) {
+	for _, s := range []string{"DNBANOKK", "DNBANOKKXXX", "nordnokk"} {
+		if err := ValidateBIC(s); err != nil {
+			t.Errorf("expected %q to be valid: %v", s, err)
+		}
+	}
+	for _, s := range []string{"DNBANOK", "DNB1NOKK", "DNBANOKKXX"} {
+		if err := ValidateBIC(s); err == nil {
+			t.Errorf("expected %q to be invalid", s)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package fiken
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// KID check digit algorithms.
+const (
+	KIDMod10 = "MOD10"
+	KIDMod11 = "MOD11"
+)
+
+// A KID is 2 to 25 characters: digits followed by a check digit, which is
+// "-" when a MOD11 remainder gives 10.
+const (
+	kidMinLength = 2
+	kidMaxLength = 25
+)
+
+// KIDCheckDigit returns the check digit for base using algorithm (MOD10 or
+// MOD11).
+func KIDCheckDigit(base, algorithm string) (string, error) {
+	if base == "" || strings.Trim(base, "0123456789") != "" {
+		return "", fmt.Errorf("KID base %q must be digits only", base)
+	}
+	switch strings.ToUpper(algorithm) {
+	case KIDMod10:
+		return strconv.Itoa(mod10(base)), nil
+	case KIDMod11:
+		d := mod11(base)
+		if d == 10 {
+			return "-", nil
+		}
+		return strconv.Itoa(d), nil
+	default:
+		return "", fmt.Errorf("unknown KID algorithm %q, expected MOD10 or MOD11", algorithm)
+	}
+}
+
+// ValidateKID checks the length, characters and check digit of kid. With an
+// empty algorithm either MOD10 or MOD11 is accepted; the matching algorithm
+// is returned.
+func ValidateKID(kid, algorithm string) (string, error) {
+	if len(kid) < kidMinLength || len(kid) > kidMaxLength {
+		return "", fmt.Errorf("KID %q must be %d to %d characters", kid, kidMinLength, kidMaxLength)
+	}
+	base, check := kid[:len(kid)-1], kid[len(kid)-1:]
+	if strings.Trim(base, "0123456789") != "" || strings.Trim(check, "0123456789-") != "" {
+		return "", fmt.Errorf("KID %q must be digits, optionally ending in '-'", kid)
+	}
+	algorithms := []string{KIDMod10, KIDMod11}
+	if algorithm != "" {
+		algorithms = []string{strings.ToUpper(algorithm)}
+	}
+	for _, alg := range algorithms {
+		want, err := KIDCheckDigit(base, alg)
+		if err != nil {
+			return "", err
+		}
+		if want == check {
+			return alg, nil
+		}
+	}
+	if algorithm == "" {
+		return "", fmt.Errorf("KID %q has an invalid check digit for both MOD10 and MOD11", kid)
+	}
+	return "", fmt.Errorf("KID %q has an invalid %s check digit", kid, strings.ToUpper(algorithm))
+}
+
+// GenerateKID builds a KID from a customer number and an invoice number,
+// zero-padded to customerDigits and invoiceDigits (0 for no padding), followed
+// by a check digit. A zero customerDigits with a zero customer number leaves
+// the customer number out.
+func GenerateKID(customerNumber, invoiceNumber int64, customerDigits, invoiceDigits int, algorithm string) (string, error) {
+	if customerNumber < 0 || invoiceNumber < 0 {
+		return "", fmt.Errorf("customer and invoice numbers must not be negative")
+	}
+	var base string
+	if customerNumber > 0 || customerDigits > 0 {
+		part, err := padDigits(customerNumber, customerDigits, "customer number")
+		if err != nil {
+			return "", err
+		}
+		base = part
+	}
+	part, err := padDigits(invoiceNumber, invoiceDigits, "invoice number")
+	if err != nil {
+		return "", err
+	}
+	base += part
+	if len(base)+1 > kidMaxLength {
+		return "", fmt.Errorf("KID would be %d characters, more than %d", len(base)+1, kidMaxLength)
+	}
+	check, err := KIDCheckDigit(base, algorithm)
+	if err != nil {
+		return "", err
+	}
+	return base + check, nil
+}
+
+// padDigits zero-pads n to digits.
+func padDigits(n int64, digits int, what string) (string, error) {
+	s := strconv.FormatInt(n, 10)
+	if digits > 0 && len(s) > digits {
+		return "", fmt.Errorf("%s %d has more than %d digits", what, n, digits)
+	}
+	if len(s) < digits {
+		s = strings.Repeat("0", digits-len(s)) + s
+	}
+	return s, nil
+}
+
+// mod10 computes the Luhn check digit: from the right, every other digit
+// starting with the rightmost is doubled and the digit sums are added.
+func mod10(base string) int {
+	sum := 0
+	for i := 0; i < len(base); i++ {
+		d := int(base[len(base)-1-i] - '0')
+		if i%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return (10 - sum%10) % 10
+}
+
+// mod11 computes the MOD11 check digit with weights 2 to 7 from the right.
+// It returns 10 when the check digit is undefined ("-").
+func mod11(base string) int {
+	sum := 0
+	for i := 0; i < len(base); i++ {
+		sum += int(base[len(base)-1-i]-'0') * (2 + i%6)
+	}
+	d := 11 - sum%11
+	if d == 11 {
+		return 0
+	}
+	return d
+}
@@ -0,0 +1,48 @@
+package fiken
+
+import "testing"
+
+func TestValidateKID(t *testing.T) {
+	tests := []struct {
+		kid       string
+		algorithm string
+		want      string
+		valid     bool
+	}{
+		{"79927398713", "", KIDMod10, true},
+		{"1234567892", "", KIDMod11, true},
+		{"1234567892", "mod11", KIDMod11, true},
+		{"40-", "", KIDMod11, true},
+		{"40-", KIDMod10, "", false},
+		{"79927398713", KIDMod11, "", false},
+		{"0000123455", "", KIDMod10, true},
+		{"79927398710", "", "", false},
+		{"1", "", "", false},
+		{"12a4", "", "", false},
+	}
+	for _, tt := range tests {
+		got, err := ValidateKID(tt.kid, tt.algorithm)
+		if (err == nil) != tt.valid || got != tt.want {
+			t.Errorf("ValidateKID(%q, %q) = %q, %v; want %q, valid %v", tt.kid, tt.algorithm, got, err, tt.want, tt.valid)
+		}
+	}
+}
+
+func TestGenerateKID(t *testing.T) {
+	kid, err := GenerateKID(42, 1001, 5, 6, KIDMod10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if kid[:11] != "00042001001" || len(kid) != 12 {
+		t.Errorf("unexpected KID %q", kid)
+	}
+	if alg, err := ValidateKID(kid, ""); err != nil || alg != KIDMod10 {
+		t.Errorf("generated KID %q does not validate: %v", kid, err)
+	}
+	if kid, err := GenerateKID(0, 40, 0, 0, KIDMod11); err != nil || kid != "40-" {
+		t.Errorf("GenerateKID without customer number = %q, %v", kid, err)
+	}
+	if _, err := GenerateKID(123456, 1, 5, 6, KIDMod10); err == nil {
+		t.Error("expected an error for a customer number longer than its digits")
+	}
+}
@@ -0,0 +1,137 @@
+package fiken
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// LineInput is an order line to calculate. Quantity and Discount are
+// decimal strings; empty means a quantity of 1 and no discount.
+type LineInput struct {
+	Description string `json:"description,omitempty"`
+	Quantity    string `json:"quantity,omitempty"`
+	// UnitPrice is the unit price excluding VAT.
+	UnitPrice Amount `json:"unitPrice"`
+	// Discount is a percentage, e.g. "10" or "12.5".
+	Discount string `json:"discount,omitempty"`
+	VatType  string `json:"vatType"`
+}
+
+// LineResult is a calculated line.
+type LineResult struct {
+	LineInput
+	VatCode string `json:"vatCode,omitempty"`
+	// VatRate is the rate charged on the line in basis points (2500 = 25 %).
+	VatRate int64  `json:"vatRate"`
+	Net     Amount `json:"net"`
+	Vat     Amount `json:"vat"`
+	Gross   Amount `json:"gross"`
+}
+
+// LinesResult is a set of calculated lines with their totals.
+type LinesResult struct {
+	Lines []LineResult `json:"lines"`
+	Net   Amount       `json:"net"`
+	Vat   Amount       `json:"vat"`
+	Gross Amount       `json:"gross"`
+}
+
+// LineVatRate returns the VAT rate charged on a line of the given Fiken
+// vatType, in basis points, and the VAT code it is reported under. Exempt,
+// outside-scope and reverse-charge types charge no VAT on the line. Purchase
+// types that are VAT only (*_DIRECT) are rejected, since the whole line
+// amount is VAT.
+func LineVatRate(vatType string, purchase bool) (rate int64, code string, err error) {
+	var ok bool
+	if purchase {
+		code, ok = PurchaseVatCode(vatType)
+	} else {
+		code, ok = SaleVatCode(vatType)
+	}
+	kind := "sale"
+	if purchase {
+		kind = "purchase"
+	}
+	if !ok {
+		return 0, "", fmt.Errorf("unknown %s vatType %q", kind, vatType)
+	}
+	if purchase && strings.HasSuffix(vatType, "_DIRECT") {
+		return 0, "", fmt.Errorf("vatType %s is for lines that are only VAT; enter the VAT amount directly", vatType)
+	}
+	vc := VatCodes[code]
+	// Reverse charge and import basis codes are output VAT the buyer
+	// calculates; the supplier's line carries none.
+	if code == "" || (purchase && vc.Output) {
+		return 0, code, nil
+	}
+	return vc.Rate, code, nil
+}
+
+// CalculateLine computes a line the way Fiken does: the net amount is
+// quantity × unit price less the discount, and the VAT is the net amount at
+// the vatType's rate, each rounded half away from zero to the øre. Gross is
+// net plus VAT.
+func CalculateLine(in LineInput, purchase bool) (LineResult, error) {
+	r := LineResult{LineInput: in}
+	quantity := big.NewRat(1, 1)
+	if in.Quantity != "" {
+		if _, ok := quantity.SetString(strings.TrimSpace(in.Quantity)); !ok {
+			return LineResult{}, fmt.Errorf("invalid quantity %q", in.Quantity)
+		}
+	}
+	discount := new(big.Rat)
+	if in.Discount != "" {
+		if _, ok := discount.SetString(strings.TrimSpace(in.Discount)); !ok {
+			return LineResult{}, fmt.Errorf("invalid discount %q", in.Discount)
+		}
+		if discount.Sign() < 0 || discount.Cmp(big.NewRat(100, 1)) > 0 {
+			return LineResult{}, fmt.Errorf("discount %s must be between 0 and 100 percent", in.Discount)
+		}
+	}
+	var err error
+	if r.VatRate, r.VatCode, err = LineVatRate(in.VatType, purchase); err != nil {
+		return LineResult{}, err
+	}
+
+	// net = quantity × unitPrice × (100 − discount) / 100
+	net := new(big.Rat).Mul(quantity, big.NewRat(int64(in.UnitPrice), 1))
+	net.Mul(net, new(big.Rat).Sub(big.NewRat(100, 1), discount))
+	net.Quo(net, big.NewRat(100, 1))
+	r.Net = roundRat(net)
+	r.Vat = VatCode{Rate: r.VatRate}.VatOn(r.Net)
+	r.Gross = r.Net + r.Vat
+	return r, nil
+}
+
+// CalculateLines calculates each line and sums the rounded line amounts, as
+// Fiken does for document totals.
+func CalculateLines(lines []LineInput, purchase bool) (LinesResult, error) {
+	res := LinesResult{Lines: make([]LineResult, 0, len(lines))}
+	for i, in := range lines {
+		r, err := CalculateLine(in, purchase)
+		if err != nil {
+			return LinesResult{}, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		res.Lines = append(res.Lines, r)
+		res.Net += r.Net
+		res.Vat += r.Vat
+		res.Gross += r.Gross
+	}
+	return res, nil
+}
+
+// roundRat rounds r to the nearest integer, half away from zero.
+func roundRat(r *big.Rat) Amount {
+	num := new(big.Int).Set(r.Num())
+	den := r.Denom()
+	neg := num.Sign() < 0
+	num.Abs(num)
+	// (2·num + den) / (2·den) rounds half up on the absolute value.
+	num.Mul(num, big.NewInt(2)).Add(num, den)
+	q := num.Quo(num, new(big.Int).Mul(den, big.NewInt(2)))
+	if neg {
+		q.Neg(q)
+	}
+	return Amount(q.Int64())
+}
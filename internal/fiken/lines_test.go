@@ -0,0 +1,65 @@
+package fiken
+
+import "testing"
+
+func TestCalculateLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       LineInput
+		purchase bool
+		net      Amount
+		vat      Amount
+		rate     int64
+	}{
+		{"high", LineInput{Quantity: "3", UnitPrice: 19990, VatType: "HIGH"}, false, 59970, 14993, 2500},
+		{"discount", LineInput{Quantity: "1.5", UnitPrice: 99900, Discount: "12.5", VatType: "MEDIUM"}, false, 131119, 19668, 1500},
+		{"low", LineInput{UnitPrice: 12345, VatType: "LOW"}, false, 12345, 1481, 1200},
+		{"raw fish", LineInput{Quantity: "10", UnitPrice: 3333, VatType: "RAW_FISH"}, false, 33330, 3703, 1111},
+		{"exempt", LineInput{Quantity: "2", UnitPrice: 50000, VatType: "EXEMPT"}, false, 100000, 0, 0},
+		{"outside", LineInput{UnitPrice: 50000, VatType: "OUTSIDE"}, false, 50000, 0, 0},
+		{"credit", LineInput{Quantity: "-1", UnitPrice: 1001, VatType: "HIGH"}, false, -1001, -250, 2500},
+		{"purchase", LineInput{Quantity: "1", UnitPrice: 80000, VatType: "HIGH"}, true, 80000, 20000, 2500},
+		{"reverse charge", LineInput{UnitPrice: 80000, VatType: "HIGH_FOREIGN_SERVICE_DEDUCTIBLE"}, true, 80000, 0, 0},
+	}
+	for _, tt := range tests {
+		r, err := CalculateLine(tt.in, tt.purchase)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if r.Net != tt.net || r.Vat != tt.vat || r.Gross != tt.net+tt.vat || r.VatRate != tt.rate {
+			t.Errorf("%s: got net %s, vat %s, gross %s, rate %d; want net %s, vat %s, rate %d",
+				tt.name, r.Net, r.Vat, r.Gross, r.VatRate, tt.net, tt.vat, tt.rate)
+		}
+	}
+}
+
+func TestCalculateLineErrors(t *testing.T) {
+	for _, in := range []LineInput{
+		{UnitPrice: 100, VatType: "HIGHEST"},
+		{UnitPrice: 100, VatType: "HIGH", Quantity: "two"},
+		{UnitPrice: 100, VatType: "HIGH", Discount: "120"},
+	} {
+		if _, err := CalculateLine(in, false); err == nil {
+			t.Errorf("expected an error for %+v", in)
+		}
+	}
+	if _, err := CalculateLine(LineInput{UnitPrice: 100, VatType: "HIGH_DIRECT"}, true); err == nil {
+		t.Error("expected an error for a VAT-only purchase line")
+	}
+}
+
+func TestCalculateLines(t *testing.T) {
+	res, err := CalculateLines([]LineInput{
+		{UnitPrice: 33, VatType: "HIGH"},
+		{UnitPrice: 33, VatType: "HIGH"},
+		{UnitPrice: 33, VatType: "HIGH"},
+	}, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Each line rounds 8.25 øre to 8; the total is the sum of the lines.
+	if res.Net != 99 || res.Vat != 24 || res.Gross != 123 {
+		t.Errorf("unexpected totals: %+v", res)
+	}
+}
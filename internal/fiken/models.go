@@ -0,0 +1,325 @@
+package fiken
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// The types below decode the subset of Fiken resources that the server
+// computes on locally. Monetary fields use Amount, so they must be decoded
+// from responses that have passed through ConvertMoneyFieldsFromOre.
+
+// Sale is a booked sale (salg).
+type Sale struct {
+	SaleID        int64       `json:"saleId"`
+	TransactionID int64       `json:"transactionId"`
+	SaleNumber    string      `json:"saleNumber"`
+	Date          string      `json:"date"`
+	Kind          string      `json:"kind"`
+	NetAmount     Amount      `json:"netAmount"`
+	VatAmount     Amount      `json:"vatAmount"`
+	Deleted       bool        `json:"deleted"`
+	Lines         []OrderLine `json:"lines"`
+	Customer      *Contact    `json:"customer"`
+	Project       ProjectRefs `json:"project"`
+}
+
+// Purchase is a booked purchase (kjøp).
+type Purchase struct {
+	PurchaseID    int64       `json:"purchaseId"`
+	TransactionID int64       `json:"transactionId"`
+	Identifier    string      `json:"identifier"`
+	Date          string      `json:"date"`
+	DueDate       string      `json:"dueDate"`
+	Kind          string      `json:"kind"`
+	Kid           string      `json:"kid"`
+	Paid          bool        `json:"paid"`
+	Deleted       bool        `json:"deleted"`
+	Lines         []OrderLine `json:"lines"`
+	Payments      []Payment   `json:"payments"`
+	Supplier      *Contact    `json:"supplier"`
+	Project       ProjectRefs `json:"project"`
+}
+
+// Payment is a payment registered against a sale or purchase.
+type Payment struct {
+	PaymentID int64  `json:"paymentId"`
+	Date      string `json:"date"`
+	Amount    Amount `json:"amount"`
+	Account   string `json:"account"`
+}
+
+// Net returns the sum of the purchase's line net prices.
+func (p Purchase) Net() Amount {
+	var total Amount
+	for _, l := range p.Lines {
+		total += l.NetPrice
+	}
+	return total
+}
+
+// Gross returns the sum of the purchase's line net prices and VAT.
+func (p Purchase) Gross() Amount {
+	var total Amount
+	for _, l := range p.Lines {
+		total += l.NetPrice + l.Vat
+	}
+	return total
+}
+
+// Outstanding returns the purchase's gross amount less registered payments.
+func (p Purchase) Outstanding() Amount {
+	total := p.Gross()
+	for _, pm := range p.Payments {
+		total -= pm.Amount
+	}
+	return total
+}
+
+// OutstandingOn returns the amount that was unpaid at the end of date
+// (YYYY-MM-DD): the gross amount less the payments dated on or before it.
+func (p Purchase) OutstandingOn(date string) Amount {
+	return p.Outstanding() + paidAfter(p.Payments, date)
+}
+
+// paidAfter sums the payments dated after date (YYYY-MM-DD).
+func paidAfter(payments []Payment, date string) Amount {
+	var total Amount
+	for _, pm := range payments {
+		if pm.Date > date {
+			total += pm.Amount
+		}
+	}
+	return total
+}
+
+// OrderLine is a line on a sale or purchase.
+type OrderLine struct {
+	Description string `json:"description"`
+	NetPrice    Amount `json:"netPrice"`
+	Vat         Amount `json:"vat"`
+	Account     string `json:"account"`
+	VatType     string `json:"vatType"`
+	ProjectID   int64  `json:"projectId"`
+}
+
+// Contact is a customer or supplier.
+type Contact struct {
+	ContactID           int64    `json:"contactId"`
+	Name                string   `json:"name"`
+	OrganizationNumber  string   `json:"organizationNumber"`
+	BankAccountNumber   string   `json:"bankAccountNumber"`
+	Email               string   `json:"email"`
+	PhoneNumber         string   `json:"phoneNumber"`
+	Customer            bool     `json:"customer"`
+	Supplier            bool     `json:"supplier"`
+	CustomerNumber      int64    `json:"customerNumber"`
+	SupplierNumber      int64    `json:"supplierNumber"`
+	CustomerAccountCode string   `json:"customerAccountCode"`
+	SupplierAccountCode string   `json:"supplierAccountCode"`
+	Inactive            bool     `json:"inactive"`
+	Address             *Address `json:"address"`
+}
+
+// Address is a postal address on a contact or company.
+type Address struct {
+	StreetAddress      string `json:"streetAddress"`
+	StreetAddressLine2 string `json:"streetAddressLine2"`
+	City               string `json:"city"`
+	PostCode           string `json:"postCode"`
+	Country            string `json:"country"`
+}
+
+// Company is a Fiken company (regnskap).
+type Company struct {
+	Name               string   `json:"name"`
+	Slug               string   `json:"slug"`
+	OrganizationNumber string   `json:"organizationNumber"`
+	Email              string   `json:"email"`
+	PhoneNumber        string   `json:"phoneNumber"`
+	Address            *Address `json:"address"`
+}
+
+// Account is an account in the company's chart of accounts.
+type Account struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// JournalEntry is a posted voucher (bilag) with its lines.
+type JournalEntry struct {
+	JournalEntryID     int64              `json:"journalEntryId"`
+	TransactionID      int64              `json:"transactionId"`
+	JournalEntryNumber int64              `json:"journalEntryNumber"`
+	Description        string             `json:"description"`
+	Date               string             `json:"date"`
+	Lines              []JournalEntryLine `json:"lines"`
+}
+
+// JournalEntryLine is a single posting. Amount is positive for debit and
+// negative for credit.
+type JournalEntryLine struct {
+	Amount    Amount `json:"amount"`
+	Account   string `json:"account"`
+	VatCode   string `json:"vatCode"`
+	ProjectID int64  `json:"projectId"`
+}
+
+// ProjectRefs holds the project IDs a document is tagged with. Fiken returns
+// the project either as a single object or as a list of objects depending on
+// the resource, so both are accepted.
+type ProjectRefs []int64
+
+// UnmarshalJSON decodes a project object, a list of project objects, or null.
+func (p *ProjectRefs) UnmarshalJSON(data []byte) error {
+	type project struct {
+		ProjectID int64 `json:"projectId"`
+	}
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		var list []project
+		if err := json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		for _, pr := range list {
+			*p = append(*p, pr.ProjectID)
+		}
+		return nil
+	}
+	var single *project
+	if err := json.Unmarshal(data, &single); err != nil {
+		return err
+	}
+	if single != nil {
+		*p = append(*p, single.ProjectID)
+	}
+	return nil
+}
+
+// Contains reports whether the given project ID is referenced.
+func (p ProjectRefs) Contains(id int64) bool {
+	for _, v := range p {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// AccountNumber returns the numeric ledger account of a Fiken account code,
+// dropping any sub-ledger suffix ("1500:10001" → 1500). It returns 0 when the
+// code does not start with a number.
+func AccountNumber(code string) int {
+	main, _, _ := strings.Cut(code, ":")
+	n := 0
+	for _, r := range main {
+		if r < '0' || r > '9' {
+			return 0
+		}
+		n = n*10 + int(r-'0')
+	}
+	return n
+}
+
+// Activity is a time-tracking activity (e.g. "Development").
+type Activity struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// TimeEntry is a registered block of time on an activity.
+type TimeEntry struct {
+	ID          int64  `json:"id"`
+	Date        string `json:"date"`
+	Minutes     int64  `json:"minutes"`
+	Description string `json:"description"`
+	ActivityID  int64  `json:"activityId"`
+	ProjectID   int64  `json:"projectId"`
+	TimeUserID  int64  `json:"timeUserId"`
+	Invoiced    bool   `json:"invoiced"`
+}
+
+// AccountBalance is an account's closing balance on a date, as returned by
+// the accountBalances endpoint. Debit balances are positive.
+type AccountBalance struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Balance Amount `json:"balance"`
+}
+
+// BankAccount is a bank account registered in Fiken, with its ledger
+// account code (e.g. "1920:10001").
+type BankAccount struct {
+	BankAccountID     int64  `json:"bankAccountId"`
+	Name              string `json:"name"`
+	AccountCode       string `json:"accountCode"`
+	BankAccountNumber string `json:"bankAccountNumber"`
+}
+
+// BankBalance is the balance the bank reports for a bank account on a date.
+type BankBalance struct {
+	BankAccountID   int64  `json:"bankAccountId"`
+	BankAccountCode string `json:"bankAccountCode"`
+	Date            string `json:"date"`
+	Balance         Amount `json:"balance"`
+}
+
+// Invoice is an issued invoice. Its payment status lives on the sale it
+// created.
+type Invoice struct {
+	InvoiceID     int64    `json:"invoiceId"`
+	InvoiceNumber int64    `json:"invoiceNumber"`
+	Kid           string   `json:"kid"`
+	IssueDate     string   `json:"issueDate"`
+	DueDate       string   `json:"dueDate"`
+	Gross         Amount   `json:"gross"`
+	Settled       bool     `json:"settled"`
+	Customer      *Contact `json:"customer"`
+	Sale          *struct {
+		OutstandingBalance Amount    `json:"outstandingBalance"`
+		Settled            bool      `json:"settled"`
+		SalePayments       []Payment `json:"salePayments"`
+	} `json:"sale"`
+}
+
+// Outstanding returns the unpaid amount of the invoice, falling back to the
+// gross amount when the sale is not included in the response.
+func (i Invoice) Outstanding() Amount {
+	if i.Sale != nil {
+		return i.Sale.OutstandingBalance
+	}
+	return i.Gross
+}
+
+// OutstandingOn returns the amount that was unpaid at the end of date
+// (YYYY-MM-DD): the outstanding amount plus the payments dated after it.
+func (i Invoice) OutstandingOn(date string) Amount {
+	if i.Sale != nil {
+		return i.Sale.OutstandingBalance + paidAfter(i.Sale.SalePayments, date)
+	}
+	return i.Gross
+}
+
+// Draft is a sale or purchase draft: a transaction not yet posted.
+type Draft struct {
+	DraftID          int64  `json:"draftId"`
+	InvoiceNumber    string `json:"invoiceNumber"`
+	InvoiceIssueDate string `json:"invoiceIssueDate"`
+	DueDate          string `json:"dueDate"`
+	Kid              string `json:"kid"`
+	Contacts         []struct {
+		ContactID int64  `json:"contactId"`
+		Name      string `json:"name"`
+	} `json:"contacts"`
+	Lines []struct {
+		Gross Amount `json:"gross"`
+	} `json:"lines"`
+}
+
+// Gross returns the sum of the draft's line gross amounts.
+func (d Draft) Gross() Amount {
+	var total Amount
+	for _, l := range d.Lines {
+		total += l.Gross
+	}
+	return total
+}
@@ -0,0 +1,44 @@
+package fiken
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPurchaseOutstandingOn(t *testing.T) {
+	p := Purchase{
+		Lines: []OrderLine{{NetPrice: 80000, Vat: 20000}},
+		Payments: []Payment{
+			{Date: "2026-03-10", Amount: 30000},
+			{Date: "2026-04-02", Amount: 70000},
+		},
+	}
+	tests := []struct {
+		date string
+		want Amount
+	}{
+		{"2026-03-09", 100000},
+		{"2026-03-10", 70000},
+		{"2026-03-31", 70000},
+		{"2026-04-02", 0},
+	}
+	for _, tt := range tests {
+		if got := p.OutstandingOn(tt.date); got != tt.want {
+			t.Errorf("OutstandingOn(%s) = %s, want %s", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestInvoiceOutstandingOn(t *testing.T) {
+	var inv Invoice
+	if err := json.Unmarshal([]byte(`{"gross":1250.00,"settled":true,"sale":{"outstandingBalance":0,"settled":true,`+
+		`"salePayments":[{"date":"2026-03-15","amount":250.00},{"date":"2026-04-05","amount":1000.00}]}}`), &inv); err != nil {
+		t.Fatal(err)
+	}
+	if got := inv.OutstandingOn("2026-03-31"); got != 100000 {
+		t.Errorf("OutstandingOn(2026-03-31) = %s, want 1000.00", got)
+	}
+	if got := inv.OutstandingOn("2026-04-05"); got != 0 {
+		t.Errorf("OutstandingOn(2026-04-05) = %s, want 0.00", got)
+	}
+}
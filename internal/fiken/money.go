@@ -2,7 +2,9 @@ package fiken
 
 import (
 	"encoding/json"
+	"fmt"
 	"math"
+	"strconv"
 	"strings"
 )
 
@@ -19,6 +21,15 @@ var moneyFields = map[string]bool{
 	"netInNok":    true,
 	"grossInNok":  true,
 	"vatInNok":    true,
+	// Sale and purchase lines carry their prices as netPrice/grossPrice.
+	"netPrice":           true,
+	"grossPrice":         true,
+	"netPriceInCurrency": true,
+	"vatInCurrency":      true,
+	// Payment status on sales and invoices.
+	"outstandingBalance":  true,
+	"totalPaid":           true,
+	"totalPaidInCurrency": true,
 }
 
 // isMoneyField returns true if the field name represents a monetary value stored in øre.
@@ -108,3 +119,92 @@ func convertValueToOre(v interface{}, fieldName string) interface{} {
 		return val
 	}
 }
+
+// Amount is a monetary value held in øre. It decodes from and encodes to a
+// decimal NOK number, the representation used in converted API responses and
+// tool output, so amounts can be summed and compared without float drift.
+type Amount int64
+
+// ParseAmount parses a decimal NOK string such as "1234.5" or "-0.07" into an
+// Amount. Values with more than two decimals are rounded to the nearest øre.
+func ParseAmount(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty amount")
+	}
+	neg := false
+	digits := s
+	switch digits[0] {
+	case '-':
+		neg = true
+		digits = digits[1:]
+	case '+':
+		digits = digits[1:]
+	}
+	whole, frac, hasFrac := strings.Cut(digits, ".")
+	if whole == "" || !isDigits(whole) || (hasFrac && !isDigits(frac)) || len(frac) > 2 {
+		// Exponents, stray characters and sub-øre precision go through float parsing.
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid amount %q", s)
+		}
+		return Amount(math.Round(f * 100)), nil
+	}
+	for len(frac) < 2 {
+		frac += "0"
+	}
+	ore, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if neg {
+		ore = -ore
+	}
+	return Amount(ore), nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// NOK returns the amount in kroner. Use it only for presentation or ratios;
+// keep sums in Amount.
+func (a Amount) NOK() float64 {
+	return float64(a) / 100
+}
+
+// String formats the amount as decimal NOK with two decimals, e.g. "-1234.50".
+func (a Amount) String() string {
+	sign := ""
+	ore := int64(a)
+	if ore < 0 {
+		sign = "-"
+		ore = -ore
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, ore/100, ore%100)
+}
+
+// MarshalJSON encodes the amount as a decimal NOK number.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON decodes a decimal NOK number (or numeric string) into øre.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	s = strings.Trim(s, `"`)
+	v, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
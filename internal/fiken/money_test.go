@@ -64,6 +64,24 @@ func TestConvertMoneyFieldsFromOre(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:  "converts sale and purchase line prices",
+			input: `{"lines":[{"netPrice":80000,"vat":20000}]}`,
+			check: func(t *testing.T, result map[string]interface{}) {
+				line := result["lines"].([]interface{})[0].(map[string]interface{})
+				assertFloat(t, line, "netPrice", 800.0)
+				assertFloat(t, line, "vat", 200.0)
+			},
+		},
+		{
+			name:  "converts sale payment status fields",
+			input: `{"sale":{"outstandingBalance":12550,"totalPaid":0}}`,
+			check: func(t *testing.T, result map[string]interface{}) {
+				sale := result["sale"].(map[string]interface{})
+				assertFloat(t, sale, "outstandingBalance", 125.5)
+				assertFloat(t, sale, "totalPaid", 0)
+			},
+		},
 		{
 			name:  "returns original on invalid JSON",
 			input: `not json`,
@@ -111,6 +129,18 @@ func TestConvertMoneyFieldsToOre(t *testing.T) {
 				assertFloat(t, result, "amount", 10000)
 			},
 		},
+		{
+			name:  "converts sale and purchase line prices",
+			input: `{"lines":[{"netPrice":800,"vat":200,"grossPrice":1000,"netPriceInCurrency":80.5,"vatInCurrency":20.13}]}`,
+			check: func(t *testing.T, result map[string]interface{}) {
+				line := result["lines"].([]interface{})[0].(map[string]interface{})
+				assertFloat(t, line, "netPrice", 80000)
+				assertFloat(t, line, "vat", 20000)
+				assertFloat(t, line, "grossPrice", 100000)
+				assertFloat(t, line, "netPriceInCurrency", 8050)
+				assertFloat(t, line, "vatInCurrency", 2013)
+			},
+		},
 		{
 			name:  "converts fields ending in Amount",
 			input: `{"netAmount":100,"grossAmount":125}`,
@@ -141,6 +171,59 @@ func TestConvertMoneyFieldsToOre(t *testing.T) {
 	}
 }
 
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Amount
+	}{
+		{"0", 0},
+		{"1234.5", 123450},
+		{"1234.56", 123456},
+		{"-0.07", -7},
+		{"+12", 1200},
+		{"99.999", 10000},
+		{"1e3", 100000},
+	}
+	for _, tt := range tests {
+		got, err := ParseAmount(tt.input)
+		if err != nil {
+			t.Errorf("ParseAmount(%q): unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAmount(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "abc", "1,50"} {
+		if _, err := ParseAmount(bad); err == nil {
+			t.Errorf("ParseAmount(%q): expected error", bad)
+		}
+	}
+}
+
+func TestAmountJSONRoundTrip(t *testing.T) {
+	// 0.1 + 0.2 is the classic float trap; summing Amounts must stay exact.
+	var parsed struct {
+		A Amount `json:"a"`
+		B Amount `json:"b"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":0.1,"b":0.2}`), &parsed); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	sum := parsed.A + parsed.B
+	out, err := json.Marshal(sum)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if string(out) != "0.30" {
+		t.Errorf("expected 0.30, got %s", out)
+	}
+	if Amount(-5).String() != "-0.05" {
+		t.Errorf("expected -0.05, got %s", Amount(-5).String())
+	}
+}
+
 func assertFloat(t *testing.T, m map[string]interface{}, key string, expected float64) {
 	t.Helper()
 	val, ok := m[key]
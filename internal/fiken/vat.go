@@ -0,0 +1,106 @@
+package fiken
+
+// VatCode is a standard Norwegian VAT code (SAF-T / MVA-melding code).
+type VatCode struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	// Rate is the VAT rate in basis points (2500 = 25 %).
+	Rate int64 `json:"rate"`
+	// Output is true when VAT on the basis is payable (utgående or beregnet mva).
+	Output bool `json:"output"`
+	// Deductible is true when VAT on the basis is deducted as input VAT.
+	Deductible bool `json:"deductible"`
+	// Sales is true for codes reported on turnover; the rest apply to purchases.
+	Sales bool `json:"sales"`
+}
+
+// VatOn returns the VAT on basis at the code's rate, rounded half away from
+// zero to the nearest øre.
+func (c VatCode) VatOn(basis Amount) Amount {
+	return Amount(divRound(int64(basis)*c.Rate, 10000))
+}
+
+// divRound divides a by b (b > 0), rounding half away from zero.
+func divRound(a, b int64) int64 {
+	if a < 0 {
+		return -((-a + b/2) / b)
+	}
+	return (a + b/2) / b
+}
+
+// VatCodes lists the standard VAT codes reported in the MVA-melding.
+var VatCodes = map[string]VatCode{
+	"1":  {Code: "1", Description: "Fradrag for inngående mva, alminnelig sats", Rate: 2500, Deductible: true},
+	"11": {Code: "11", Description: "Fradrag for inngående mva, middels sats", Rate: 1500, Deductible: true},
+	"12": {Code: "12", Description: "Fradrag for inngående mva, råfisk", Rate: 1111, Deductible: true},
+	"13": {Code: "13", Description: "Fradrag for inngående mva, redusert sats", Rate: 1200, Deductible: true},
+	"14": {Code: "14", Description: "Fradrag for innførselsmva, alminnelig sats", Rate: 2500, Deductible: true},
+	"15": {Code: "15", Description: "Fradrag for innførselsmva, middels sats", Rate: 1500, Deductible: true},
+	"3":  {Code: "3", Description: "Utgående mva, alminnelig sats", Rate: 2500, Output: true, Sales: true},
+	"31": {Code: "31", Description: "Utgående mva, middels sats", Rate: 1500, Output: true, Sales: true},
+	"32": {Code: "32", Description: "Utgående mva, råfisk", Rate: 1111, Output: true, Sales: true},
+	"33": {Code: "33", Description: "Utgående mva, redusert sats", Rate: 1200, Output: true, Sales: true},
+	"5":  {Code: "5", Description: "Innenlands omsetning fritatt for mva (nullsats)", Sales: true},
+	"51": {Code: "51", Description: "Innenlands omsetning med omvendt avgiftsplikt", Sales: true},
+	"52": {Code: "52", Description: "Utførsel av varer og tjenester, fritatt", Sales: true},
+	"6":  {Code: "6", Description: "Omsetning unntatt merverdiavgiftsloven", Sales: true},
+	"81": {Code: "81", Description: "Innførsel av varer med fradrag, alminnelig sats", Rate: 2500, Output: true, Deductible: true},
+	"82": {Code: "82", Description: "Innførsel av varer uten fradrag, alminnelig sats", Rate: 2500, Output: true},
+	"83": {Code: "83", Description: "Innførsel av varer med fradrag, middels sats", Rate: 1500, Output: true, Deductible: true},
+	"84": {Code: "84", Description: "Innførsel av varer uten fradrag, middels sats", Rate: 1500, Output: true},
+	"85": {Code: "85", Description: "Innførsel av varer, nullsats"},
+	"86": {Code: "86", Description: "Tjenester kjøpt fra utlandet med fradrag, alminnelig sats", Rate: 2500, Output: true, Deductible: true},
+	"87": {Code: "87", Description: "Tjenester kjøpt fra utlandet uten fradrag, alminnelig sats", Rate: 2500, Output: true},
+	"88": {Code: "88", Description: "Tjenester kjøpt fra utlandet med fradrag, redusert sats", Rate: 1200, Output: true, Deductible: true},
+	"89": {Code: "89", Description: "Tjenester kjøpt fra utlandet uten fradrag, redusert sats", Rate: 1200, Output: true},
+	"91": {Code: "91", Description: "Kjøp av klimakvoter eller gull med fradrag", Rate: 2500, Output: true, Deductible: true},
+	"92": {Code: "92", Description: "Kjøp av klimakvoter eller gull uten fradrag", Rate: 2500, Output: true},
+}
+
+// saleVatTypes maps Fiken's vatType values on sales to VAT codes. NONE means
+// the line has no VAT treatment and is not reported.
+var saleVatTypes = map[string]string{
+	"HIGH":                 "3",
+	"MEDIUM":               "31",
+	"RAW_FISH":             "32",
+	"LOW":                  "33",
+	"EXEMPT":               "5",
+	"EXEMPT_REVERSE":       "51",
+	"EXEMPT_IMPORT_EXPORT": "52",
+	"OUTSIDE":              "6",
+	"NONE":                 "",
+}
+
+// purchaseVatTypes maps Fiken's vatType values on purchases to VAT codes.
+var purchaseVatTypes = map[string]string{
+	"HIGH":                               "1",
+	"MEDIUM":                             "11",
+	"RAW_FISH":                           "12",
+	"LOW":                                "13",
+	"HIGH_DIRECT":                        "14",
+	"MEDIUM_DIRECT":                      "15",
+	"HIGH_BASIS":                         "81",
+	"MEDIUM_BASIS":                       "83",
+	"NONE_IMPORT_BASIS":                  "85",
+	"HIGH_FOREIGN_SERVICE_DEDUCTIBLE":    "86",
+	"HIGH_FOREIGN_SERVICE_NONDEDUCTIBLE": "87",
+	"LOW_FOREIGN_SERVICE_DEDUCTIBLE":     "88",
+	"LOW_FOREIGN_SERVICE_NONDEDUCTIBLE":  "89",
+	"HIGH_PURCHASE_OF_EMISSIONSTRADING_OR_GOLD_DEDUCTIBLE":    "91",
+	"HIGH_PURCHASE_OF_EMISSIONSTRADING_OR_GOLD_NONDEDUCTIBLE": "92",
+	"NONE": "",
+}
+
+// SaleVatCode returns the VAT code for a Fiken sale vatType. ok is false for
+// unknown types; an empty code with ok true means no VAT treatment.
+func SaleVatCode(vatType string) (code string, ok bool) {
+	code, ok = saleVatTypes[vatType]
+	return code, ok
+}
+
+// PurchaseVatCode returns the VAT code for a Fiken purchase vatType. ok is
+// false for unknown types; an empty code with ok true means no VAT treatment.
+func PurchaseVatCode(vatType string) (code string, ok bool) {
+	code, ok = purchaseVatTypes[vatType]
+	return code, ok
+}
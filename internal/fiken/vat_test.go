@@ -0,0 +1,46 @@
+package fiken
+
+import "testing"
+
+func TestVatOn(t *testing.T) {
+	tests := []struct {
+		code  string
+		basis Amount
+		want  Amount
+	}{
+		{"3", 10000, 2500},
+		{"31", 333, 50}, // 49.95 øre rounds up
+		{"33", -1250, -150},
+		{"12", 10000, 1111},
+		{"5", 10000, 0},
+	}
+	for _, tt := range tests {
+		if got := VatCodes[tt.code].VatOn(tt.basis); got != tt.want {
+			t.Errorf("code %s VatOn(%d) = %d, want %d", tt.code, tt.basis, got, tt.want)
+		}
+	}
+}
+
+func TestVatTypeCodes(t *testing.T) {
+	for vatType, code := range saleVatTypes {
+		if code == "" {
+			continue
+		}
+		vc, ok := VatCodes[code]
+		if !ok || !vc.Sales {
+			t.Errorf("sale vatType %s maps to %q, which is not a sales code", vatType, code)
+		}
+	}
+	for vatType, code := range purchaseVatTypes {
+		if code == "" {
+			continue
+		}
+		vc, ok := VatCodes[code]
+		if !ok || vc.Sales {
+			t.Errorf("purchase vatType %s maps to %q, which is not a purchase code", vatType, code)
+		}
+	}
+	if _, ok := SaleVatCode("BOGUS"); ok {
+		t.Error("expected unknown vatType to be reported")
+	}
+}
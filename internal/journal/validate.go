@@ -0,0 +1,186 @@
+// Package journal validates general journal entry requests before they are
+// sent to Fiken.
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+const dateLayout = "2006-01-02"
+
+// Request is a general journal entry request. A body with lines and no
+// journalEntries is read as a single entry.
+type Request struct {
+	Description    string  `json:"description"`
+	JournalEntries []Entry `json:"journalEntries"`
+}
+
+// Entry is one journal entry in a request.
+type Entry struct {
+	Description string `json:"description"`
+	Date        string `json:"date"`
+	Lines       []Line `json:"lines"`
+}
+
+// Line is a journal entry line. It either posts a signed Amount (positive
+// for debit) on Account, or a positive Amount on DebitAccount and/or
+// CreditAccount.
+type Line struct {
+	Amount        fiken.Amount `json:"amount"`
+	Account       string       `json:"account"`
+	VatCode       string       `json:"vatCode"`
+	DebitAccount  string       `json:"debitAccount"`
+	DebitVatCode  string       `json:"debitVatCode"`
+	CreditAccount string       `json:"creditAccount"`
+	CreditVatCode string       `json:"creditVatCode"`
+}
+
+// ParseRequest decodes a request body. Amounts are in NOK, as the tools
+// receive them.
+func ParseRequest(body []byte) (Request, error) {
+	var r Request
+	if err := json.Unmarshal(body, &r); err != nil {
+		return Request{}, fmt.Errorf("invalid journal entry JSON: %w", err)
+	}
+	if len(r.JournalEntries) == 0 {
+		var e Entry
+		if err := json.Unmarshal(body, &e); err == nil && len(e.Lines) > 0 {
+			r.JournalEntries = []Entry{e}
+		}
+	}
+	return r, nil
+}
+
+// Problem is one validation failure, located by its JSON path.
+type Problem struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (p Problem) String() string {
+	return p.Path + ": " + p.Message
+}
+
+// Rules are the company-specific checks.
+type Rules struct {
+	// Accounts is the chart of accounts. When empty, account codes are only
+	// checked for format.
+	Accounts []fiken.Account
+	// ClosedUntil is the last date of the closed period (YYYY-MM-DD); entries
+	// on or before it are rejected. Empty means no closed period.
+	ClosedUntil string
+}
+
+// Validate checks that every entry has a valid date outside the closed
+// period and lines that balance to the øre, on existing accounts, with VAT
+// codes that exist and suit the account.
+func Validate(r Request, rules Rules) []Problem {
+	chart := make(map[string]bool, len(rules.Accounts))
+	for _, a := range rules.Accounts {
+		chart[a.Code] = true
+	}
+	var problems []Problem
+	add := func(path, format string, args ...interface{}) {
+		problems = append(problems, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(r.JournalEntries) == 0 {
+		add("journalEntries", "at least one journal entry is required")
+	}
+	for i, e := range r.JournalEntries {
+		path := fmt.Sprintf("journalEntries[%d]", i)
+		if _, err := time.Parse(dateLayout, e.Date); err != nil {
+			add(path+".date", "invalid date %q, expected YYYY-MM-DD", e.Date)
+		} else if rules.ClosedUntil != "" && e.Date <= rules.ClosedUntil {
+			add(path+".date", "%s is in the closed period (closed until %s)", e.Date, rules.ClosedUntil)
+		}
+		if len(e.Lines) == 0 {
+			add(path+".lines", "at least one line is required")
+			continue
+		}
+
+		var debit, credit fiken.Amount
+		for j, l := range e.Lines {
+			lp := fmt.Sprintf("%s.lines[%d]", path, j)
+			if l.Amount == 0 {
+				add(lp+".amount", "amount is zero")
+			}
+			switch {
+			case l.Account != "":
+				if l.DebitAccount != "" || l.CreditAccount != "" {
+					add(lp, "use either account or debitAccount/creditAccount, not both")
+					continue
+				}
+				if l.Amount > 0 {
+					debit += l.Amount
+				} else {
+					credit -= l.Amount
+				}
+				checkPosting(add, lp+".account", lp+".vatCode", l.Account, l.VatCode, chart)
+			case l.DebitAccount != "" || l.CreditAccount != "":
+				if l.Amount < 0 {
+					add(lp+".amount", "amount must be positive with debitAccount/creditAccount, got %s", l.Amount)
+				}
+				if l.DebitAccount != "" {
+					debit += l.Amount
+					checkPosting(add, lp+".debitAccount", lp+".debitVatCode", l.DebitAccount, l.DebitVatCode, chart)
+				}
+				if l.CreditAccount != "" {
+					credit += l.Amount
+					checkPosting(add, lp+".creditAccount", lp+".creditVatCode", l.CreditAccount, l.CreditVatCode, chart)
+				}
+			default:
+				add(lp, "an account, debitAccount or creditAccount is required")
+			}
+		}
+		if debit != credit {
+			add(path+".lines", "debit %s and credit %s do not balance (difference %s)", debit, credit, debit-credit)
+		}
+	}
+	return problems
+}
+
+// checkPosting checks an account code and the VAT code posted with it.
+func checkPosting(add func(path, format string, args ...interface{}), accountPath, vatPath, account, vatCode string, chart map[string]bool) {
+	base, sub, hasSub := strings.Cut(account, ":")
+	number, err := strconv.Atoi(base)
+	if err != nil || len(base) != 4 || (hasSub && sub == "") {
+		add(accountPath, "invalid account code %q, expected four digits, optionally with a sub-account (e.g. 1500:10001)", account)
+		return
+	}
+	if len(chart) > 0 && !chart[account] && !chart[base] {
+		add(accountPath, "account %s is not in the chart of accounts", account)
+	}
+
+	if vatCode == "" || vatCode == "0" {
+		return
+	}
+	vc, ok := fiken.VatCodes[vatCode]
+	if !ok {
+		add(vatPath, "unknown VAT code %q", vatCode)
+		return
+	}
+	if !vatCodeAllowed(vc, number) {
+		add(vatPath, "VAT code %s (%s) cannot be used on account %s", vc.Code, vc.Description, account)
+	}
+}
+
+// vatCodeAllowed reports whether a VAT code may be posted on an account:
+// sales codes on revenue accounts (3000–3999), purchase codes on fixed
+// assets (1000–1299) and expenses (4000–7999). The import VAT deduction codes
+// 14 and 15 may also be posted on the VAT accounts (2700–2799).
+func vatCodeAllowed(vc fiken.VatCode, account int) bool {
+	if vc.Sales {
+		return account >= 3000 && account <= 3999
+	}
+	if (vc.Code == "14" || vc.Code == "15") && account >= 2700 && account <= 2799 {
+		return true
+	}
+	return (account >= 1000 && account <= 1299) || (account >= 4000 && account <= 7999)
+}
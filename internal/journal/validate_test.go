@@ -0,0 +1,74 @@
+package journal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+var chart = []fiken.Account{
+	{Code: "1500", Name: "Kundefordringer"},
+	{Code: "1920:10001", Name: "Driftskonto"},
+	{Code: "3000", Name: "Salgsinntekt"},
+	{Code: "6800", Name: "Kontorrekvisita"},
+	{Code: "2400", Name: "Leverandørgjeld"},
+}
+
+func TestValidateBalancedEntry(t *testing.T) {
+	r, err := ParseRequest([]byte(`{"description": "Rekvisita", "journalEntries": [{"date": "2026-03-01", "lines": [
+		{"amount": 100.10, "account": "6800", "vatCode": "1"},
+		{"amount": -100.10, "account": "1920:10001"},
+		{"amount": 50, "debitAccount": "1500:10002", "creditAccount": "3000", "creditVatCode": "3"}
+	]}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if problems := Validate(r, Rules{Accounts: chart, ClosedUntil: "2026-02-28"}); len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+}
+
+func TestValidateSingleEntryBody(t *testing.T) {
+	r, err := ParseRequest([]byte(`{"date": "2026-03-01", "lines": [{"amount": 1, "account": "6800"}, {"amount": -1, "account": "2400"}]}`))
+	if err != nil || len(r.JournalEntries) != 1 {
+		t.Fatalf("expected one entry, got %+v, %v", r, err)
+	}
+	if problems := Validate(r, Rules{}); len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+}
+
+func TestValidateProblems(t *testing.T) {
+	r, err := ParseRequest([]byte(`{"journalEntries": [{"date": "2026-01-31", "lines": [
+		{"amount": 100.00, "account": "6801", "vatCode": "3"},
+		{"amount": -99.99, "account": "1920:10001", "vatCode": "1"},
+		{"amount": 10, "account": "68OO"},
+		{"amount": 5, "debitAccount": "6800", "debitVatCode": "7"}
+	]}, {"date": "2026-3-1", "lines": []}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	problems := Validate(r, Rules{Accounts: chart, ClosedUntil: "2026-02-28"})
+
+	want := []string{
+		"journalEntries[0].date: 2026-01-31 is in the closed period (closed until 2026-02-28)",
+		"journalEntries[0].lines[0].account: account 6801 is not in the chart of accounts",
+		"journalEntries[0].lines[0].vatCode: VAT code 3 (Utgående mva, alminnelig sats) cannot be used on account 6801",
+		"journalEntries[0].lines[1].vatCode: VAT code 1 (Fradrag for inngående mva, alminnelig sats) cannot be used on account 1920:10001",
+		"journalEntries[0].lines[2].account: invalid account code \"68OO\"",
+		"journalEntries[0].lines[3].debitVatCode: unknown VAT code \"7\"",
+		"journalEntries[0].lines: debit 115.00 and credit 99.99 do not balance (difference 15.01)",
+		"journalEntries[1].date: invalid date \"2026-3-1\"",
+		"journalEntries[1].lines: at least one line is required",
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("got %d problems, want %d:\n%v", len(problems), len(want), problems)
+	}
+	for i, p := range problems {
+		if !strings.HasPrefix(p.String(), want[i]) {
+			t.Errorf("problem %d = %q, want prefix %q", i, p.String(), want[i])
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package report
+
+import (
+	"sort"
+	"time"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// AgingBuckets splits an outstanding amount by days past due.
+type AgingBuckets struct {
+	Current    fiken.Amount `json:"current"`
+	Days1To30  fiken.Amount `json:"days1To30"`
+	Days31To60 fiken.Amount `json:"days31To60"`
+	Days61To90 fiken.Amount `json:"days61To90"`
+	Over90     fiken.Amount `json:"over90"`
+	Total      fiken.Amount `json:"total"`
+}
+
+func (b *AgingBuckets) add(daysOverdue int, amount fiken.Amount) string {
+	b.Total += amount
+	switch {
+	case daysOverdue <= 0:
+		b.Current += amount
+		return "current"
+	case daysOverdue <= 30:
+		b.Days1To30 += amount
+		return "1-30"
+	case daysOverdue <= 60:
+		b.Days31To60 += amount
+		return "31-60"
+	case daysOverdue <= 90:
+		b.Days61To90 += amount
+		return "61-90"
+	default:
+		b.Over90 += amount
+		return "90+"
+	}
+}
+
+// AgingItem is one open document in an aging report.
+type AgingItem struct {
+	ID          int64        `json:"id"`
+	Number      string       `json:"number,omitempty"`
+	Date        string       `json:"date"`
+	DueDate     string       `json:"dueDate"`
+	DaysOverdue int          `json:"daysOverdue"`
+	Bucket      string       `json:"bucket"`
+	Outstanding fiken.Amount `json:"outstanding"`
+	// Kid and BankAccountNumber are set on payables to help plan payments.
+	Kid               string `json:"kid,omitempty"`
+	BankAccountNumber string `json:"bankAccountNumber,omitempty"`
+}
+
+// AgingParty groups the open documents of one customer or supplier.
+type AgingParty struct {
+	ContactID int64        `json:"contactId"`
+	Name      string       `json:"name"`
+	Buckets   AgingBuckets `json:"buckets"`
+	Items     []AgingItem  `json:"items"`
+}
+
+// AgingReport is an aging of open receivables or payables as of a date.
+type AgingReport struct {
+	AsOf    string       `json:"asOf"`
+	Totals  AgingBuckets `json:"totals"`
+	Parties []AgingParty `json:"parties"`
+}
+
+// OpenItem is an outstanding document to be aged.
+type OpenItem struct {
+	ContactID int64
+	Name      string
+	Item      AgingItem
+}
+
+// BuildAging buckets open items by days past due as of asOf and groups them
+// per contact. Items dated after asOf or with nothing outstanding are left
+// out. Parties are ordered by total outstanding, largest first.
+func BuildAging(asOf time.Time, items []OpenItem) AgingReport {
+	asOfDate := asOf.Format("2006-01-02")
+	r := AgingReport{AsOf: asOfDate, Parties: []AgingParty{}}
+	byContact := make(map[int64]*AgingParty)
+	var order []int64
+
+	for _, oi := range items {
+		if oi.Item.Outstanding == 0 || oi.Item.Date > asOfDate {
+			continue
+		}
+		item := oi.Item
+		if due, err := time.Parse("2006-01-02", item.DueDate); err == nil {
+			item.DaysOverdue = int(asOf.Sub(due).Hours() / 24)
+		}
+		party, ok := byContact[oi.ContactID]
+		if !ok {
+			party = &AgingParty{ContactID: oi.ContactID, Name: oi.Name}
+			byContact[oi.ContactID] = party
+			order = append(order, oi.ContactID)
+		}
+		item.Bucket = party.Buckets.add(item.DaysOverdue, item.Outstanding)
+		r.Totals.add(item.DaysOverdue, item.Outstanding)
+		party.Items = append(party.Items, item)
+	}
+
+	for _, id := range order {
+		party := byContact[id]
+		sort.SliceStable(party.Items, func(i, j int) bool { return party.Items[i].DueDate < party.Items[j].DueDate })
+		r.Parties = append(r.Parties, *party)
+	}
+	sort.SliceStable(r.Parties, func(i, j int) bool { return r.Parties[i].Buckets.Total > r.Parties[j].Buckets.Total })
+	return r
+}
+
+// UpcomingPayment is an open item due within a payment planning window.
+type UpcomingPayment struct {
+	ContactID int64  `json:"contactId"`
+	Name      string `json:"name"`
+	AgingItem
+}
+
+// DueWithin returns the open items due on or before asOf plus days, ordered
+// by due date. Overdue items are included so they are not missed in a
+// payment run; their DaysOverdue is positive.
+func DueWithin(asOf time.Time, days int, items []OpenItem) []UpcomingPayment {
+	asOfDate := asOf.Format("2006-01-02")
+	until := asOf.AddDate(0, 0, days).Format("2006-01-02")
+	upcoming := []UpcomingPayment{}
+	for _, oi := range items {
+		if oi.Item.Outstanding == 0 || oi.Item.Date > asOfDate || oi.Item.DueDate > until {
+			continue
+		}
+		item := oi.Item
+		if due, err := time.Parse("2006-01-02", item.DueDate); err == nil {
+			item.DaysOverdue = int(asOf.Sub(due).Hours() / 24)
+		}
+		upcoming = append(upcoming, UpcomingPayment{ContactID: oi.ContactID, Name: oi.Name, AgingItem: item})
+	}
+	sort.SliceStable(upcoming, func(i, j int) bool { return upcoming[i].DueDate < upcoming[j].DueDate })
+	return upcoming
+}
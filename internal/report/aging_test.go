@@ -0,0 +1,56 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func TestBuildAging(t *testing.T) {
+	asOf := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+	items := []OpenItem{
+		{ContactID: 1, Name: "Acme", Item: AgingItem{ID: 10, Date: "2026-06-01", DueDate: "2026-07-01", Outstanding: 10000}},
+		{ContactID: 1, Name: "Acme", Item: AgingItem{ID: 11, Date: "2026-05-01", DueDate: "2026-05-31", Outstanding: 2050}},
+		{ContactID: 2, Name: "Globex", Item: AgingItem{ID: 12, Date: "2026-01-01", DueDate: "2026-03-01", Outstanding: 50000}},
+		{ContactID: 2, Name: "Globex", Item: AgingItem{ID: 13, Date: "2026-04-01", DueDate: "2026-04-30", Outstanding: 1}},
+		{ContactID: 2, Name: "Globex", Item: AgingItem{ID: 14, Date: "2026-07-15", DueDate: "2026-07-30", Outstanding: 999}},
+		{ContactID: 3, Name: "Paid", Item: AgingItem{ID: 15, Date: "2026-01-01", DueDate: "2026-01-15", Outstanding: 0}},
+	}
+
+	r := BuildAging(asOf, items)
+
+	if r.Totals.Total != 62051 || r.Totals.Current != 10000 || r.Totals.Days1To30 != 2050 ||
+		r.Totals.Days31To60 != 0 || r.Totals.Days61To90 != 1 || r.Totals.Over90 != 50000 {
+		t.Errorf("unexpected totals: %+v", r.Totals)
+	}
+	if len(r.Parties) != 2 || r.Parties[0].Name != "Globex" {
+		t.Fatalf("expected Globex first of 2 parties, got %+v", r.Parties)
+	}
+	globex := r.Parties[0]
+	if len(globex.Items) != 2 || globex.Items[0].ID != 12 || globex.Items[0].DaysOverdue != 121 || globex.Items[0].Bucket != "90+" {
+		t.Errorf("unexpected Globex items: %+v", globex.Items)
+	}
+	if acme := r.Parties[1]; acme.Buckets.Total != fiken.Amount(12050) {
+		t.Errorf("expected Acme total 12050, got %d", acme.Buckets.Total)
+	}
+}
+
+func TestDueWithin(t *testing.T) {
+	asOf := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+	items := []OpenItem{
+		{ContactID: 1, Name: "Supplier A", Item: AgingItem{ID: 1, Date: "2026-06-20", DueDate: "2026-07-10", Outstanding: 500, Kid: "1234567890"}},
+		{ContactID: 2, Name: "Supplier B", Item: AgingItem{ID: 2, Date: "2026-06-01", DueDate: "2026-06-25", Outstanding: 100}},
+		{ContactID: 2, Name: "Supplier B", Item: AgingItem{ID: 3, Date: "2026-06-10", DueDate: "2026-07-08", Outstanding: 300}},
+		{ContactID: 3, Name: "Supplier C", Item: AgingItem{ID: 4, Date: "2026-06-25", DueDate: "2026-07-08", Outstanding: 0}},
+	}
+
+	got := DueWithin(asOf, 8, items)
+
+	if len(got) != 2 || got[0].ID != 2 || got[1].ID != 3 {
+		t.Fatalf("expected items 2 and 3 in due date order, got %+v", got)
+	}
+	if got[0].DaysOverdue != 5 || got[1].DaysOverdue != -8 || got[1].Name != "Supplier B" {
+		t.Errorf("unexpected upcoming payments: %+v", got)
+	}
+}
@@ -0,0 +1,62 @@
+package report
+
+import "github.com/simenandre/fiken-mcp/internal/fiken"
+
+// BalanceSheet is a statement of financial position (balanse) on a date.
+// Assets are shown as positive debit balances, equity and liabilities as
+// positive credit balances.
+type BalanceSheet struct {
+	Date                   string        `json:"date"`
+	FixedAssets            Section       `json:"fixedAssets"`
+	CurrentAssets          Section       `json:"currentAssets"`
+	TotalAssets            fiken.Amount  `json:"totalAssets"`
+	Equity                 Section       `json:"equity"`
+	UnallocatedResult      fiken.Amount  `json:"unallocatedResult"`
+	LongTermLiabilities    Section       `json:"longTermLiabilities"`
+	CurrentLiabilities     Section       `json:"currentLiabilities"`
+	TotalEquityLiabilities fiken.Amount  `json:"totalEquityAndLiabilities"`
+	Balanced               bool          `json:"balanced"`
+	Imbalance              fiken.Amount  `json:"imbalance"`
+	Comparison             *BalanceSheet `json:"comparison,omitempty"`
+}
+
+// BuildBalanceSheet groups closing balances on date by NS 4102 class:
+//
+//	1000–1399 fixed assets, 1400–1999 current assets, 2000–2099 equity,
+//	2100–2299 long-term liabilities, 2300–2999 current liabilities.
+//
+// Balances on result accounts (3000–8999) are summed into UnallocatedResult,
+// the profit for the year that has not yet been closed to equity, which is
+// needed for the sheet to balance during the year. Imbalance is total assets
+// minus total equity and liabilities and should be zero.
+func BuildBalanceSheet(date string, balances []fiken.AccountBalance) BalanceSheet {
+	bs := BalanceSheet{
+		Date:                date,
+		FixedAssets:         newSection("Anleggsmidler"),
+		CurrentAssets:       newSection("Omløpsmidler"),
+		Equity:              newSection("Egenkapital"),
+		LongTermLiabilities: newSection("Langsiktig gjeld"),
+		CurrentLiabilities:  newSection("Kortsiktig gjeld"),
+	}
+	for _, b := range sortedByCode(balances) {
+		switch n := fiken.AccountNumber(b.Code); {
+		case n >= 1000 && n < 1400:
+			bs.FixedAssets.add(b.Code, b.Name, b.Balance)
+		case n >= 1400 && n < 2000:
+			bs.CurrentAssets.add(b.Code, b.Name, b.Balance)
+		case n >= 2000 && n < 2100:
+			bs.Equity.add(b.Code, b.Name, -b.Balance)
+		case n >= 2100 && n < 2300:
+			bs.LongTermLiabilities.add(b.Code, b.Name, -b.Balance)
+		case n >= 2300 && n < 3000:
+			bs.CurrentLiabilities.add(b.Code, b.Name, -b.Balance)
+		case n >= 3000 && n < 9000:
+			bs.UnallocatedResult -= b.Balance
+		}
+	}
+	bs.TotalAssets = bs.FixedAssets.Total + bs.CurrentAssets.Total
+	bs.TotalEquityLiabilities = bs.Equity.Total + bs.UnallocatedResult + bs.LongTermLiabilities.Total + bs.CurrentLiabilities.Total
+	bs.Imbalance = bs.TotalAssets - bs.TotalEquityLiabilities
+	bs.Balanced = bs.Imbalance == 0
+	return bs
+}
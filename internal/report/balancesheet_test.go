@@ -0,0 +1,41 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func TestBuildBalanceSheet(t *testing.T) {
+	balances := []fiken.AccountBalance{
+		{Code: "1200", Name: "Maskiner", Balance: 500000},
+		{Code: "1500:10001", Name: "Kundefordringer", Balance: 125000},
+		{Code: "1920", Name: "Bank", Balance: 300050},
+		{Code: "2000", Name: "Aksjekapital", Balance: -300000},
+		{Code: "2250", Name: "Lån", Balance: -400000},
+		{Code: "2400:20001", Name: "Leverandørgjeld", Balance: -50000},
+		{Code: "2700", Name: "Utgående mva", Balance: -25000},
+		{Code: "3000", Name: "Salgsinntekt", Balance: -200000},
+		{Code: "6300", Name: "Leie", Balance: 49950},
+	}
+
+	bs := BuildBalanceSheet("2026-06-30", balances)
+
+	if bs.TotalAssets != 925050 {
+		t.Errorf("total assets: expected 925050, got %d", bs.TotalAssets)
+	}
+	if bs.UnallocatedResult != 150050 {
+		t.Errorf("unallocated result: expected 150050, got %d", bs.UnallocatedResult)
+	}
+	if bs.CurrentLiabilities.Total != 75000 {
+		t.Errorf("current liabilities: expected 75000, got %d", bs.CurrentLiabilities.Total)
+	}
+	if !bs.Balanced || bs.Imbalance != 0 {
+		t.Errorf("expected a balanced sheet, got imbalance %d", bs.Imbalance)
+	}
+
+	bs = BuildBalanceSheet("2026-06-30", balances[:len(balances)-1])
+	if bs.Balanced || bs.Imbalance != -49950 {
+		t.Errorf("expected imbalance -49950, got balanced=%v imbalance=%d", bs.Balanced, bs.Imbalance)
+	}
+}
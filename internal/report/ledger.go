@@ -0,0 +1,155 @@
+package report
+
+import (
+	"sort"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// GeneralLedger is the account statement (hovedbok) for a range of accounts
+// over a period.
+type GeneralLedger struct {
+	Period      Period          `json:"period"`
+	FromAccount int             `json:"fromAccount"`
+	ToAccount   int             `json:"toAccount"`
+	Accounts    []LedgerAccount `json:"accounts"`
+	// Verified is true when every account's computed opening and closing
+	// balances equal the balances reported by Fiken.
+	Verified bool `json:"verified"`
+}
+
+// LedgerAccount is one account's opening balance, postings and closing
+// balance in a general ledger. Opening and Closing are computed from the
+// postings; Difference is Closing minus ReportedClosing and
+// OpeningDifference is Opening minus ReportedOpening.
+type LedgerAccount struct {
+	Code              string          `json:"code"`
+	Name              string          `json:"name"`
+	Opening           fiken.Amount    `json:"opening"`
+	ReportedOpening   fiken.Amount    `json:"reportedOpening"`
+	OpeningDifference fiken.Amount    `json:"openingDifference"`
+	Postings          []LedgerPosting `json:"postings"`
+	Closing           fiken.Amount    `json:"closing"`
+	ReportedClosing   fiken.Amount    `json:"reportedClosing"`
+	Difference        fiken.Amount    `json:"difference"`
+	Verified          bool            `json:"verified"`
+}
+
+// LedgerPosting is a single journal entry line with the account's running
+// balance after it.
+type LedgerPosting struct {
+	Date               string       `json:"date"`
+	JournalEntryID     int64        `json:"journalEntryId"`
+	JournalEntryNumber int64        `json:"journalEntryNumber"`
+	TransactionID      int64        `json:"transactionId,omitempty"`
+	Description        string       `json:"description"`
+	VatCode            string       `json:"vatCode,omitempty"`
+	Amount             fiken.Amount `json:"amount"`
+	Balance            fiken.Amount `json:"balance"`
+}
+
+// BuildGeneralLedger lists every journal entry line in the period on
+// accounts fromAccount–toAccount, ordered by date and voucher number, with a
+// running balance. The ledger is verified at both ends: the opening balance,
+// computed as yearOpening plus the entries from the start of the fiscal year
+// to the day before the period, is checked against opening, and the closing
+// balance against closing, both as reported by Fiken.
+//
+// yearOpening, opening and closing are balances the day before the fiscal
+// year containing period.From, the day before the period and on its last
+// day. Result accounts should be left out of yearOpening, and out of opening
+// when the period starts on the first day of the fiscal year. entries must
+// cover the fiscal year up to the end of the period.
+func BuildGeneralLedger(period Period, fromAccount, toAccount int, yearOpening, opening, closing []fiken.AccountBalance, entries []fiken.JournalEntry) GeneralLedger {
+	gl := GeneralLedger{Period: period, FromAccount: fromAccount, ToAccount: toAccount, Accounts: []LedgerAccount{}, Verified: true}
+	inRange := func(code string) bool {
+		n := fiken.AccountNumber(code)
+		return n >= fromAccount && n <= toAccount
+	}
+
+	byCode := make(map[string]*LedgerAccount)
+	var codes []fiken.AccountBalance
+	get := func(code, name string) *LedgerAccount {
+		a, ok := byCode[code]
+		if !ok {
+			a = &LedgerAccount{Code: code, Name: name, Postings: []LedgerPosting{}}
+			byCode[code] = a
+			codes = append(codes, fiken.AccountBalance{Code: code})
+		}
+		if a.Name == "" {
+			a.Name = name
+		}
+		return a
+	}
+	for _, b := range yearOpening {
+		if inRange(b.Code) {
+			get(b.Code, b.Name).Opening += b.Balance
+		}
+	}
+	for _, b := range opening {
+		if inRange(b.Code) {
+			get(b.Code, b.Name).ReportedOpening += b.Balance
+		}
+	}
+	for _, b := range closing {
+		if inRange(b.Code) {
+			get(b.Code, b.Name).ReportedClosing += b.Balance
+		}
+	}
+
+	sorted := append([]fiken.JournalEntry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Date != sorted[j].Date {
+			return sorted[i].Date < sorted[j].Date
+		}
+		return sorted[i].JournalEntryNumber < sorted[j].JournalEntryNumber
+	})
+	yearStart := ""
+	if len(period.From) >= 4 {
+		yearStart = period.From[:4] + "-01-01"
+	}
+	for _, e := range sorted {
+		before := e.Date >= yearStart && e.Date < period.From
+		if !before && !period.Contains(e.Date) {
+			continue
+		}
+		for _, l := range e.Lines {
+			if l.Amount == 0 || !inRange(l.Account) {
+				continue
+			}
+			a := get(l.Account, "")
+			if before {
+				a.Opening += l.Amount
+				continue
+			}
+			a.Postings = append(a.Postings, LedgerPosting{
+				Date:               e.Date,
+				JournalEntryID:     e.JournalEntryID,
+				JournalEntryNumber: e.JournalEntryNumber,
+				TransactionID:      e.TransactionID,
+				Description:        e.Description,
+				VatCode:            l.VatCode,
+				Amount:             l.Amount,
+			})
+		}
+	}
+
+	for _, c := range sortedByCode(codes) {
+		a := byCode[c.Code]
+		balance := a.Opening
+		for i := range a.Postings {
+			balance += a.Postings[i].Amount
+			a.Postings[i].Balance = balance
+		}
+		a.Closing = balance
+		a.OpeningDifference = a.Opening - a.ReportedOpening
+		a.Difference = a.Closing - a.ReportedClosing
+		a.Verified = a.OpeningDifference == 0 && a.Difference == 0
+		if a.Opening == 0 && a.ReportedOpening == 0 && a.ReportedClosing == 0 && len(a.Postings) == 0 {
+			continue
+		}
+		gl.Verified = gl.Verified && a.Verified
+		gl.Accounts = append(gl.Accounts, *a)
+	}
+	return gl
+}
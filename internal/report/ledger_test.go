@@ -0,0 +1,70 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func TestBuildGeneralLedger(t *testing.T) {
+	opening := []fiken.AccountBalance{
+		{Code: "1920", Name: "Bank", Balance: 100000},
+		{Code: "1500:10001", Name: "Kundefordringer", Balance: 0},
+		{Code: "3000", Name: "Salgsinntekt", Balance: -5000},
+	}
+	closing := []fiken.AccountBalance{
+		{Code: "1920", Name: "Bank", Balance: 112500},
+		{Code: "1500:10001", Name: "Kundefordringer", Balance: 2500},
+		{Code: "3000", Name: "Salgsinntekt", Balance: -20000},
+	}
+	entries := []fiken.JournalEntry{
+		{JournalEntryID: 2, JournalEntryNumber: 11, Date: "2026-03-05", Description: "Innbetaling", Lines: []fiken.JournalEntryLine{
+			{Account: "1920", Amount: 12500},
+			{Account: "1500:10001", Amount: -12500},
+		}},
+		{JournalEntryID: 1, JournalEntryNumber: 10, Date: "2026-03-01", Description: "Faktura 1", Lines: []fiken.JournalEntryLine{
+			{Account: "1500:10001", Amount: 15000},
+			{Account: "3000", Amount: -15000, VatCode: "6"},
+		}},
+		{JournalEntryID: 3, JournalEntryNumber: 9, Date: "2026-02-28", Lines: []fiken.JournalEntryLine{
+			{Account: "1920", Amount: 999},
+		}},
+	}
+
+	yearOpening := []fiken.AccountBalance{
+		{Code: "1920", Name: "Bank", Balance: 99001},
+	}
+
+	gl := BuildGeneralLedger(Period{From: "2026-03-01", To: "2026-03-31"}, 1000, 1999, yearOpening, opening, closing, entries)
+
+	if len(gl.Accounts) != 2 || gl.Accounts[0].Code != "1500:10001" || gl.Accounts[1].Code != "1920" {
+		t.Fatalf("expected accounts 1500:10001 and 1920, got %+v", gl.Accounts)
+	}
+	receivables := gl.Accounts[0]
+	if len(receivables.Postings) != 2 || receivables.Postings[0].JournalEntryNumber != 10 ||
+		receivables.Postings[0].Balance != 15000 || receivables.Postings[1].Balance != 2500 {
+		t.Errorf("unexpected receivable postings: %+v", receivables.Postings)
+	}
+	bank := gl.Accounts[1]
+	if bank.Opening != 100000 || bank.OpeningDifference != 0 || bank.Closing != 112500 || !bank.Verified || len(bank.Postings) != 1 {
+		t.Errorf("unexpected bank account: %+v", bank)
+	}
+	if !gl.Verified {
+		t.Error("expected the ledger to be verified")
+	}
+
+	closing[1].Balance = 2000
+	gl = BuildGeneralLedger(Period{From: "2026-03-01", To: "2026-03-31"}, 1000, 1999, yearOpening, opening, closing, entries)
+	if gl.Verified || gl.Accounts[0].Difference != 500 {
+		t.Errorf("expected a difference of 500 on 1500:10001, got %+v", gl.Accounts[0])
+	}
+	closing[1].Balance = 2500
+
+	// The opening balance must follow from the previous period.
+	opening[0].Balance = 100500
+	gl = BuildGeneralLedger(Period{From: "2026-03-01", To: "2026-03-31"}, 1000, 1999, yearOpening, opening, closing, entries)
+	bank = gl.Accounts[1]
+	if gl.Verified || bank.Verified || bank.Opening != 100000 || bank.ReportedOpening != 100500 || bank.OpeningDifference != -500 {
+		t.Errorf("expected an opening difference of -500 on 1920, got %+v", bank)
+	}
+}
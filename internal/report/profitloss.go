@@ -0,0 +1,136 @@
+package report
+
+import (
+	"sort"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// AccountLine is one account's contribution to a report section.
+type AccountLine struct {
+	Code   string       `json:"code"`
+	Name   string       `json:"name"`
+	Amount fiken.Amount `json:"amount"`
+}
+
+// Section is a group of accounts with an exact subtotal.
+type Section struct {
+	Label    string        `json:"label"`
+	Total    fiken.Amount  `json:"total"`
+	Accounts []AccountLine `json:"accounts"`
+}
+
+func newSection(label string) Section {
+	return Section{Label: label, Accounts: []AccountLine{}}
+}
+
+func (s *Section) add(code, name string, amount fiken.Amount) {
+	if amount == 0 {
+		return
+	}
+	s.Total += amount
+	s.Accounts = append(s.Accounts, AccountLine{Code: code, Name: name, Amount: amount})
+}
+
+// ProfitAndLoss is an income statement grouped by the Norwegian standard
+// chart of accounts (NS 4102). Income is shown as positive amounts and costs
+// as positive amounts to be subtracted.
+type ProfitAndLoss struct {
+	Period                 Period         `json:"period"`
+	Revenue                Section        `json:"revenue"`
+	CostOfGoods            Section        `json:"costOfGoods"`
+	Payroll                Section        `json:"payroll"`
+	OtherOperatingExpenses Section        `json:"otherOperatingExpenses"`
+	OperatingResult        fiken.Amount   `json:"operatingResult"`
+	FinancialItems         Section        `json:"financialItems"`
+	ResultBeforeTax        fiken.Amount   `json:"resultBeforeTax"`
+	Tax                    Section        `json:"tax"`
+	Result                 fiken.Amount   `json:"result"`
+	Comparison             *ProfitAndLoss `json:"comparison,omitempty"`
+}
+
+// BuildProfitAndLoss groups period movements on result accounts:
+//
+//	3000–3999 revenue, 4000–4999 cost of goods, 5000–5999 payroll,
+//	6000–7999 other operating expenses, 8000–8299 and 8400–8799 financial
+//	items, 8300–8399 tax.
+//
+// Accounts 8800–8999 (annual result and allocations) are left out, since
+// they only move the result to equity.
+func BuildProfitAndLoss(period Period, movements []fiken.AccountBalance) ProfitAndLoss {
+	pl := ProfitAndLoss{
+		Period:                 period,
+		Revenue:                newSection("Driftsinntekter"),
+		CostOfGoods:            newSection("Varekostnad"),
+		Payroll:                newSection("Lønnskostnader"),
+		OtherOperatingExpenses: newSection("Andre driftskostnader"),
+		FinancialItems:         newSection("Finansposter"),
+		Tax:                    newSection("Skattekostnad"),
+	}
+	for _, m := range sortedByCode(movements) {
+		switch n := fiken.AccountNumber(m.Code); {
+		case n >= 3000 && n < 4000:
+			// Income is credited (negative), so flip the sign for presentation.
+			pl.Revenue.add(m.Code, m.Name, -m.Balance)
+		case n >= 4000 && n < 5000:
+			pl.CostOfGoods.add(m.Code, m.Name, m.Balance)
+		case n >= 5000 && n < 6000:
+			pl.Payroll.add(m.Code, m.Name, m.Balance)
+		case n >= 6000 && n < 8000:
+			pl.OtherOperatingExpenses.add(m.Code, m.Name, m.Balance)
+		case n >= 8300 && n < 8400:
+			pl.Tax.add(m.Code, m.Name, m.Balance)
+		case n >= 8000 && n < 8800:
+			pl.FinancialItems.add(m.Code, m.Name, -m.Balance)
+		}
+	}
+	pl.OperatingResult = pl.Revenue.Total - pl.CostOfGoods.Total - pl.Payroll.Total - pl.OtherOperatingExpenses.Total
+	pl.ResultBeforeTax = pl.OperatingResult + pl.FinancialItems.Total
+	pl.Result = pl.ResultBeforeTax - pl.Tax.Total
+	return pl
+}
+
+// Movements returns closing minus opening balance per account, i.e. what was
+// posted between the two dates. Accounts present in only one list are
+// included; a nil opening means the period starts at zero.
+func Movements(opening, closing []fiken.AccountBalance) []fiken.AccountBalance {
+	byCode := make(map[string]*fiken.AccountBalance)
+	var order []string
+	get := func(b fiken.AccountBalance) *fiken.AccountBalance {
+		m, ok := byCode[b.Code]
+		if !ok {
+			m = &fiken.AccountBalance{Code: b.Code, Name: b.Name}
+			byCode[b.Code] = m
+			order = append(order, b.Code)
+		}
+		return m
+	}
+	for _, b := range closing {
+		get(b).Balance += b.Balance
+	}
+	for _, b := range opening {
+		get(b).Balance -= b.Balance
+	}
+	result := make([]fiken.AccountBalance, 0, len(order))
+	for _, code := range order {
+		result = append(result, *byCode[code])
+	}
+	return result
+}
+
+// sortedByCode returns a copy of balances ordered by account number and then
+// by the full code, so sub-ledger accounts follow their main account.
+func sortedByCode(balances []fiken.AccountBalance) []fiken.AccountBalance {
+	sorted := append([]fiken.AccountBalance(nil), balances...)
+	sort.SliceStable(sorted, func(i, j int) bool { return codeLess(sorted[i].Code, sorted[j].Code) })
+	return sorted
+}
+
+// codeLess orders account codes by account number and then by full code.
+func codeLess(a, b string) bool {
+	na, nb := fiken.AccountNumber(a), fiken.AccountNumber(b)
+	if na != nb {
+		return na < nb
+	}
+	return a < b
+}
@@ -0,0 +1,51 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func TestBuildProfitAndLoss(t *testing.T) {
+	opening := []fiken.AccountBalance{
+		{Code: "3000", Name: "Salgsinntekt", Balance: -100000},
+		{Code: "4300", Name: "Innkjøp", Balance: 20000},
+	}
+	closing := []fiken.AccountBalance{
+		{Code: "3000", Name: "Salgsinntekt", Balance: -350010},
+		{Code: "4300", Name: "Innkjøp", Balance: 70000},
+		{Code: "5000", Name: "Lønn", Balance: 100000},
+		{Code: "6300", Name: "Leie lokale", Balance: 25005},
+		{Code: "8040", Name: "Renteinntekt", Balance: -1000},
+		{Code: "8150", Name: "Rentekostnad", Balance: 300},
+		{Code: "8300", Name: "Betalbar skatt", Balance: 10000},
+		{Code: "8800", Name: "Årsresultat", Balance: 99999},
+		{Code: "1920", Name: "Bank", Balance: 50000},
+	}
+
+	pl := BuildProfitAndLoss(Period{From: "2026-03-01", To: "2026-04-30"}, Movements(opening, closing))
+
+	checks := []struct {
+		name string
+		got  fiken.Amount
+		want fiken.Amount
+	}{
+		{"revenue", pl.Revenue.Total, 250010},
+		{"cost of goods", pl.CostOfGoods.Total, 50000},
+		{"payroll", pl.Payroll.Total, 100000},
+		{"other opex", pl.OtherOperatingExpenses.Total, 25005},
+		{"operating result", pl.OperatingResult, 75005},
+		{"financial items", pl.FinancialItems.Total, 700},
+		{"result before tax", pl.ResultBeforeTax, 75705},
+		{"tax", pl.Tax.Total, 10000},
+		{"result", pl.Result, 65705},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s: expected %d øre, got %d", c.name, c.want, c.got)
+		}
+	}
+	if len(pl.FinancialItems.Accounts) != 2 {
+		t.Errorf("expected 2 financial accounts, got %d", len(pl.FinancialItems.Accounts))
+	}
+}
@@ -0,0 +1,153 @@
+// Package report computes accounting reports from Fiken data locally, using
+// exact øre arithmetic.
+package report
+
+import (
+	"math"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// Period is an inclusive date range in YYYY-MM-DD form. Empty bounds are open.
+type Period struct {
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// Contains reports whether date falls within the period.
+func (p Period) Contains(date string) bool {
+	if p.From != "" && date < p.From {
+		return false
+	}
+	if p.To != "" && date > p.To {
+		return false
+	}
+	return true
+}
+
+// ProjectSummary is the profitability of a single project over a period.
+type ProjectSummary struct {
+	ProjectID      int64         `json:"projectId"`
+	Period         Period        `json:"period"`
+	Revenue        fiken.Amount  `json:"revenue"`
+	Cost           fiken.Amount  `json:"cost"`
+	Margin         fiken.Amount  `json:"margin"`
+	MarginPercent  *float64      `json:"marginPercent"`
+	Sales          []ProjectItem `json:"sales"`
+	Purchases      []ProjectItem `json:"purchases"`
+	JournalEntries []ProjectItem `json:"journalEntries"`
+}
+
+// ProjectItem is a document contributing to a project summary.
+type ProjectItem struct {
+	ID          int64        `json:"id"`
+	Date        string       `json:"date"`
+	Description string       `json:"description,omitempty"`
+	Revenue     fiken.Amount `json:"revenue"`
+	Cost        fiken.Amount `json:"cost"`
+}
+
+// SummarizeProject aggregates the sales, purchases and journal entry lines
+// tagged with projectID within period.
+//
+// Revenue is the net (ex. VAT) amount of sales tagged with the project, or
+// of their lines tagged with it; cost is the net amount of purchase lines,
+// likewise. Journal entry lines on revenue (3xxx) and expense
+// (4xxx–7xxx) accounts are included only when their transaction is not one of
+// the counted sales or purchases, so nothing is counted twice.
+func SummarizeProject(projectID int64, period Period, sales []fiken.Sale, purchases []fiken.Purchase, entries []fiken.JournalEntry) ProjectSummary {
+	summary := ProjectSummary{
+		ProjectID:      projectID,
+		Period:         period,
+		Sales:          []ProjectItem{},
+		Purchases:      []ProjectItem{},
+		JournalEntries: []ProjectItem{},
+	}
+	counted := make(map[int64]bool)
+
+	for _, s := range sales {
+		if s.Deleted || !period.Contains(s.Date) {
+			continue
+		}
+		revenue := s.NetAmount
+		if !s.Project.Contains(projectID) {
+			revenue = 0
+			matched := false
+			for _, l := range s.Lines {
+				if l.ProjectID == projectID {
+					revenue += l.NetPrice
+					matched = true
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+		summary.Revenue += revenue
+		summary.Sales = append(summary.Sales, ProjectItem{ID: s.SaleID, Date: s.Date, Description: s.SaleNumber, Revenue: revenue})
+		counted[s.TransactionID] = true
+	}
+
+	for _, p := range purchases {
+		if p.Deleted || !period.Contains(p.Date) {
+			continue
+		}
+		wholePurchase := p.Project.Contains(projectID)
+		var cost fiken.Amount
+		matched := false
+		for _, l := range p.Lines {
+			if wholePurchase || l.ProjectID == projectID {
+				cost += l.NetPrice
+				matched = true
+			}
+		}
+		if !matched {
+			continue
+		}
+		summary.Cost += cost
+		summary.Purchases = append(summary.Purchases, ProjectItem{ID: p.PurchaseID, Date: p.Date, Description: p.Identifier, Cost: cost})
+		counted[p.TransactionID] = true
+	}
+
+	for _, e := range entries {
+		if !period.Contains(e.Date) || (e.TransactionID != 0 && counted[e.TransactionID]) {
+			continue
+		}
+		item := ProjectItem{ID: e.JournalEntryID, Date: e.Date, Description: e.Description}
+		matched := false
+		for _, l := range e.Lines {
+			if l.ProjectID != projectID {
+				continue
+			}
+			switch n := fiken.AccountNumber(l.Account); {
+			case n >= 3000 && n < 4000:
+				// Revenue is credited, so a negative amount is income.
+				item.Revenue -= l.Amount
+				matched = true
+			case n >= 4000 && n < 8000:
+				item.Cost += l.Amount
+				matched = true
+			}
+		}
+		if !matched {
+			continue
+		}
+		summary.Revenue += item.Revenue
+		summary.Cost += item.Cost
+		summary.JournalEntries = append(summary.JournalEntries, item)
+	}
+
+	summary.Margin = summary.Revenue - summary.Cost
+	summary.MarginPercent = percentOf(summary.Margin, summary.Revenue)
+	return summary
+}
+
+// percentOf returns part as a percentage of whole, rounded to two decimals,
+// or nil when whole is zero.
+func percentOf(part, whole fiken.Amount) *float64 {
+	if whole == 0 {
+		return nil
+	}
+	pct := math.Round(float64(part)/float64(whole)*10000) / 100
+	return &pct
+}
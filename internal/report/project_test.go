@@ -0,0 +1,61 @@
+package report
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func TestSummarizeProject(t *testing.T) {
+	var sales []fiken.Sale
+	var purchases []fiken.Purchase
+	var entries []fiken.JournalEntry
+	mustDecode(t, `[
+		{"saleId":1,"transactionId":100,"date":"2026-03-01","netAmount":1000.10,"project":{"projectId":7}},
+		{"saleId":2,"transactionId":101,"date":"2026-03-02","netAmount":500,"project":{"projectId":8}},
+		{"saleId":3,"transactionId":102,"date":"2025-12-31","netAmount":900,"project":{"projectId":7}},
+		{"saleId":4,"transactionId":103,"date":"2026-03-03","netAmount":800,
+		 "lines":[{"netPrice":300,"projectId":7},{"netPrice":500,"projectId":8}]}
+	]`, &sales)
+	mustDecode(t, `[
+		{"purchaseId":10,"transactionId":200,"date":"2026-03-05","project":[{"projectId":7}],
+		 "lines":[{"netPrice":200.05},{"netPrice":100}]},
+		{"purchaseId":11,"transactionId":201,"date":"2026-03-06",
+		 "lines":[{"netPrice":50,"projectId":7},{"netPrice":75,"projectId":8}]}
+	]`, &purchases)
+	mustDecode(t, `[
+		{"journalEntryId":20,"transactionId":100,"date":"2026-03-01",
+		 "lines":[{"amount":-1000.10,"account":"3000","projectId":7}]},
+		{"journalEntryId":21,"transactionId":300,"date":"2026-03-10","description":"Accrual",
+		 "lines":[{"amount":40,"account":"6300","projectId":7},{"amount":-40,"account":"2900"}]}
+	]`, &entries)
+
+	got := SummarizeProject(7, Period{From: "2026-01-01", To: "2026-12-31"}, sales, purchases, entries)
+
+	// 1000.10 from sale 1 and the 300 line of sale 4.
+	if got.Revenue != 130010 {
+		t.Errorf("revenue: expected 130010 øre, got %d", got.Revenue)
+	}
+	// 200.05 + 100 + 50 from purchases, 40 from the accrual entry.
+	if got.Cost != 39005 {
+		t.Errorf("cost: expected 39005 øre, got %d", got.Cost)
+	}
+	if got.Margin != 91005 {
+		t.Errorf("margin: expected 91005 øre, got %d", got.Margin)
+	}
+	if len(got.Sales) != 2 || got.Sales[1].Revenue != 30000 || len(got.Purchases) != 2 || len(got.JournalEntries) != 1 {
+		t.Errorf("unexpected item counts: %d sales, %d purchases, %d journal entries",
+			len(got.Sales), len(got.Purchases), len(got.JournalEntries))
+	}
+	if got.MarginPercent == nil || *got.MarginPercent != 70 {
+		t.Errorf("expected margin percent 70, got %v", got.MarginPercent)
+	}
+}
+
+func mustDecode(t *testing.T, data string, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(data), v); err != nil {
+		t.Fatalf("decoding fixture: %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package report
+
+import (
+	"sort"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// TrialBalance is a saldobalanse: every account's opening balance, movement
+// in the period and closing balance, with the closing balance split into
+// debit and credit columns.
+type TrialBalance struct {
+	Period      Period             `json:"period"`
+	Accounts    []TrialBalanceLine `json:"accounts"`
+	TotalDebit  fiken.Amount       `json:"totalDebit"`
+	TotalCredit fiken.Amount       `json:"totalCredit"`
+	// Difference is TotalDebit minus TotalCredit; it is zero when the ledger
+	// balances.
+	Difference fiken.Amount `json:"difference"`
+	Balanced   bool         `json:"balanced"`
+	// ComparePeriod is set when the movements are compared with another period.
+	ComparePeriod *Period `json:"comparePeriod,omitempty"`
+}
+
+// TrialBalanceLine is one account in a trial balance. Debit and Credit are
+// the closing balance as positive amounts on the side it falls on.
+type TrialBalanceLine struct {
+	Code     string       `json:"code"`
+	Name     string       `json:"name"`
+	Opening  fiken.Amount `json:"opening"`
+	Movement fiken.Amount `json:"movement"`
+	Closing  fiken.Amount `json:"closing"`
+	Debit    fiken.Amount `json:"debit"`
+	Credit   fiken.Amount `json:"credit"`
+	// CompareMovement, Delta and DeltaPercent are set when comparing periods.
+	// DeltaPercent is relative to the size of the comparison movement and is
+	// null when that is zero.
+	CompareMovement *fiken.Amount `json:"compareMovement,omitempty"`
+	Delta           *fiken.Amount `json:"delta,omitempty"`
+	DeltaPercent    *float64      `json:"deltaPercent,omitempty"`
+}
+
+// BuildTrialBalance builds a trial balance from the balances the day before
+// the period and on its last day. Accounts with no opening balance, movement
+// or closing balance are left out.
+func BuildTrialBalance(period Period, opening, closing []fiken.AccountBalance) TrialBalance {
+	tb := TrialBalance{Period: period, Accounts: []TrialBalanceLine{}}
+	openingByCode := make(map[string]fiken.Amount)
+	for _, b := range opening {
+		openingByCode[b.Code] += b.Balance
+	}
+	for _, m := range sortedByCode(Movements(opening, closing)) {
+		line := TrialBalanceLine{
+			Code:     m.Code,
+			Name:     m.Name,
+			Opening:  openingByCode[m.Code],
+			Movement: m.Balance,
+		}
+		line.Closing = line.Opening + line.Movement
+		if line.Opening == 0 && line.Movement == 0 && line.Closing == 0 {
+			continue
+		}
+		if line.Closing > 0 {
+			line.Debit = line.Closing
+		} else {
+			line.Credit = -line.Closing
+		}
+		tb.TotalDebit += line.Debit
+		tb.TotalCredit += line.Credit
+		tb.Accounts = append(tb.Accounts, line)
+	}
+	tb.Difference = tb.TotalDebit - tb.TotalCredit
+	tb.Balanced = tb.Difference == 0
+	return tb
+}
+
+// CompareMovements adds the movements of another period to each account,
+// with the absolute and percentage change from it. Accounts that only moved
+// in the comparison period are added with zero balances.
+func (tb *TrialBalance) CompareMovements(period Period, movements []fiken.AccountBalance) {
+	tb.ComparePeriod = &period
+	byCode := make(map[string]fiken.Amount)
+	for _, m := range movements {
+		byCode[m.Code] += m.Balance
+	}
+	added := false
+	for _, m := range sortedByCode(movements) {
+		if m.Balance != 0 && !tb.hasAccount(m.Code) {
+			tb.Accounts = append(tb.Accounts, TrialBalanceLine{Code: m.Code, Name: m.Name})
+			added = true
+		}
+	}
+	if added {
+		sort.SliceStable(tb.Accounts, func(i, j int) bool { return codeLess(tb.Accounts[i].Code, tb.Accounts[j].Code) })
+	}
+
+	for i := range tb.Accounts {
+		line := &tb.Accounts[i]
+		compare := byCode[line.Code]
+		delta := line.Movement - compare
+		line.CompareMovement = &compare
+		line.Delta = &delta
+		base := compare
+		if base < 0 {
+			base = -base
+		}
+		line.DeltaPercent = percentOf(delta, base)
+	}
+}
+
+// hasAccount reports whether code is already listed.
+func (tb *TrialBalance) hasAccount(code string) bool {
+	for _, l := range tb.Accounts {
+		if l.Code == code {
+			return true
+		}
+	}
+	return false
+}
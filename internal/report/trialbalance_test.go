@@ -0,0 +1,49 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func TestBuildTrialBalance(t *testing.T) {
+	opening := []fiken.AccountBalance{
+		{Code: "1920", Name: "Bank", Balance: 100000},
+		{Code: "2000", Name: "Aksjekapital", Balance: -100000},
+	}
+	closing := []fiken.AccountBalance{
+		{Code: "1920", Name: "Bank", Balance: 130000},
+		{Code: "2000", Name: "Aksjekapital", Balance: -100000},
+		{Code: "3000", Name: "Salgsinntekt", Balance: -40000},
+		{Code: "6300", Name: "Leie lokale", Balance: 10000},
+		{Code: "7770", Name: "Bankgebyr", Balance: 0},
+	}
+
+	tb := BuildTrialBalance(Period{From: "2026-01-01", To: "2026-01-31"}, opening, closing)
+
+	if len(tb.Accounts) != 4 {
+		t.Fatalf("expected 4 accounts, got %+v", tb.Accounts)
+	}
+	if bank := tb.Accounts[0]; bank.Opening != 100000 || bank.Movement != 30000 || bank.Debit != 130000 || bank.Credit != 0 {
+		t.Errorf("unexpected bank line: %+v", bank)
+	}
+	if tb.TotalDebit != 140000 || tb.TotalCredit != 140000 || !tb.Balanced {
+		t.Errorf("expected balanced totals of 140000, got debit %d credit %d", tb.TotalDebit, tb.TotalCredit)
+	}
+
+	tb.CompareMovements(Period{From: "2025-12-01", To: "2025-12-31"}, []fiken.AccountBalance{
+		{Code: "3000", Name: "Salgsinntekt", Balance: -32000},
+		{Code: "6500", Name: "Verktøy", Balance: 5000},
+	})
+
+	if len(tb.Accounts) != 5 || tb.Accounts[4].Code != "6500" {
+		t.Fatalf("expected 6500 to be added in order, got %+v", tb.Accounts)
+	}
+	revenue := tb.Accounts[2]
+	if *revenue.Delta != -8000 || revenue.DeltaPercent == nil || *revenue.DeltaPercent != -25 {
+		t.Errorf("unexpected revenue comparison: delta %d, percent %v", *revenue.Delta, revenue.DeltaPercent)
+	}
+	if bank := tb.Accounts[0]; *bank.Delta != 30000 || bank.DeltaPercent != nil {
+		t.Errorf("expected no percentage without a comparison movement, got %+v", bank)
+	}
+}
@@ -0,0 +1,186 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// ParseVatTermin parses a two-month VAT period such as "2026-5" (September–
+// October 2026) into its date range.
+func ParseVatTermin(termin string) (Period, error) {
+	yearStr, termStr, ok := strings.Cut(termin, "-")
+	year, yearErr := strconv.Atoi(yearStr)
+	term, termErr := strconv.Atoi(termStr)
+	if !ok || yearErr != nil || termErr != nil || year < 1900 || term < 1 || term > 6 {
+		return Period{}, fmt.Errorf("invalid termin %q, expected YEAR-TERM with term 1–6, e.g. 2026-5", termin)
+	}
+	start := time.Date(year, time.Month(term*2-1), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 2, -1)
+	return Period{From: start.Format("2006-01-02"), To: end.Format("2006-01-02")}, nil
+}
+
+// VatReturn is a prepared VAT return (MVA-melding) for one termin.
+type VatReturn struct {
+	Termin         string            `json:"termin"`
+	Period         Period            `json:"period"`
+	Codes          []VatCodeLine     `json:"codes"`
+	OutputVat      fiken.Amount      `json:"outputVat"`
+	DeductibleVat  fiken.Amount      `json:"deductibleVat"`
+	Payable        fiken.Amount      `json:"payable"`
+	Reconciliation VatReconciliation `json:"reconciliation"`
+	Unmapped       []UnmappedVatLine `json:"unmapped"`
+}
+
+// VatCodeLine is the basis and VAT reported on one VAT code.
+type VatCodeLine struct {
+	fiken.VatCode
+	Basis fiken.Amount `json:"basis"`
+	Vat   fiken.Amount `json:"vat"`
+	Lines int          `json:"lines"`
+}
+
+// VatReconciliation compares the computed return with what was posted to the
+// VAT accounts (2700–2739) in the period.
+type VatReconciliation struct {
+	Accounts      []AccountLine `json:"accounts"`
+	LedgerPayable fiken.Amount  `json:"ledgerPayable"`
+	Difference    fiken.Amount  `json:"difference"`
+	Matches       bool          `json:"matches"`
+}
+
+// UnmappedVatLine is a document line whose VAT type or code is not known,
+// and therefore not included in the return.
+type UnmappedVatLine struct {
+	Source  string       `json:"source"`
+	ID      int64        `json:"id"`
+	VatType string       `json:"vatType"`
+	Basis   fiken.Amount `json:"basis"`
+}
+
+// BuildVatReturn groups the period's sale lines, purchase lines and general
+// journal entry lines by VAT code.
+//
+// Sale and purchase lines use their own VAT amounts as recorded, including
+// zero. Journal entries belonging to a counted sale or purchase transaction
+// are skipped, and for the remaining entries VAT is computed from the code's
+// rate.
+//
+// vatMovements are the period movements on the VAT accounts; the ledger
+// payable amount is their credit balance. A settlement posted against 2740
+// inside the period will show up as a difference.
+func BuildVatReturn(termin string, period Period, sales []fiken.Sale, purchases []fiken.Purchase, entries []fiken.JournalEntry, vatMovements []fiken.AccountBalance) VatReturn {
+	vr := VatReturn{Termin: termin, Period: period, Unmapped: []UnmappedVatLine{}}
+	byCode := make(map[string]*VatCodeLine)
+	counted := make(map[int64]bool)
+
+	addLine := func(code string, basis, vat fiken.Amount, explicitVat bool) {
+		vc := fiken.VatCodes[code]
+		line, ok := byCode[code]
+		if !ok {
+			line = &VatCodeLine{VatCode: vc}
+			byCode[code] = line
+		}
+		if !explicitVat {
+			vat = vc.VatOn(basis)
+		}
+		line.Basis += basis
+		line.Vat += vat
+		line.Lines++
+	}
+
+	for _, s := range sales {
+		if s.Deleted || !period.Contains(s.Date) {
+			continue
+		}
+		counted[s.TransactionID] = true
+		for _, l := range s.Lines {
+			code, ok := fiken.SaleVatCode(l.VatType)
+			if !ok {
+				vr.Unmapped = append(vr.Unmapped, UnmappedVatLine{Source: "sale", ID: s.SaleID, VatType: l.VatType, Basis: l.NetPrice})
+				continue
+			}
+			if code != "" {
+				addLine(code, l.NetPrice, l.Vat, true)
+			}
+		}
+	}
+
+	for _, p := range purchases {
+		if p.Deleted || !period.Contains(p.Date) {
+			continue
+		}
+		counted[p.TransactionID] = true
+		for _, l := range p.Lines {
+			code, ok := fiken.PurchaseVatCode(l.VatType)
+			if !ok {
+				vr.Unmapped = append(vr.Unmapped, UnmappedVatLine{Source: "purchase", ID: p.PurchaseID, VatType: l.VatType, Basis: l.NetPrice})
+				continue
+			}
+			if code != "" {
+				addLine(code, l.NetPrice, l.Vat, true)
+			}
+		}
+	}
+
+	for _, e := range entries {
+		if !period.Contains(e.Date) || (e.TransactionID != 0 && counted[e.TransactionID]) {
+			continue
+		}
+		for _, l := range e.Lines {
+			n := fiken.AccountNumber(l.Account)
+			if l.VatCode == "" || l.VatCode == "0" || (n >= 2700 && n < 2800) {
+				continue
+			}
+			vc, ok := fiken.VatCodes[l.VatCode]
+			if !ok {
+				vr.Unmapped = append(vr.Unmapped, UnmappedVatLine{Source: "journalEntry", ID: e.JournalEntryID, VatType: l.VatCode, Basis: l.Amount})
+				continue
+			}
+			basis := l.Amount
+			if vc.Sales {
+				// Turnover is credited, so a negative posting is a positive basis.
+				basis = -basis
+			}
+			addLine(l.VatCode, basis, 0, false)
+		}
+	}
+
+	codes := make([]string, 0, len(byCode))
+	for code := range byCode {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		a, _ := strconv.Atoi(codes[i])
+		b, _ := strconv.Atoi(codes[j])
+		return a < b
+	})
+	vr.Codes = make([]VatCodeLine, 0, len(codes))
+	for _, code := range codes {
+		line := byCode[code]
+		if line.Output {
+			vr.OutputVat += line.Vat
+		}
+		if line.Deductible {
+			vr.DeductibleVat += line.Vat
+		}
+		vr.Codes = append(vr.Codes, *line)
+	}
+	vr.Payable = vr.OutputVat - vr.DeductibleVat
+
+	vr.Reconciliation.Accounts = []AccountLine{}
+	for _, m := range sortedByCode(vatMovements) {
+		if n := fiken.AccountNumber(m.Code); n < 2700 || n >= 2740 || m.Balance == 0 {
+			continue
+		}
+		vr.Reconciliation.Accounts = append(vr.Reconciliation.Accounts, AccountLine{Code: m.Code, Name: m.Name, Amount: m.Balance})
+		vr.Reconciliation.LedgerPayable -= m.Balance
+	}
+	vr.Reconciliation.Difference = vr.Payable - vr.Reconciliation.LedgerPayable
+	vr.Reconciliation.Matches = vr.Reconciliation.Difference == 0
+	return vr
+}
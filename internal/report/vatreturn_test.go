@@ -0,0 +1,90 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func TestParseVatTermin(t *testing.T) {
+	p, err := ParseVatTermin("2026-5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.From != "2026-09-01" || p.To != "2026-10-31" {
+		t.Errorf("expected 2026-09-01..2026-10-31, got %s..%s", p.From, p.To)
+	}
+	p, _ = ParseVatTermin("2024-1")
+	if p.To != "2024-02-29" {
+		t.Errorf("expected leap day end, got %s", p.To)
+	}
+	for _, bad := range []string{"2026", "2026-7", "2026-0", "x-1"} {
+		if _, err := ParseVatTermin(bad); err == nil {
+			t.Errorf("ParseVatTermin(%q): expected error", bad)
+		}
+	}
+}
+
+func TestBuildVatReturn(t *testing.T) {
+	var sales []fiken.Sale
+	var purchases []fiken.Purchase
+	var entries []fiken.JournalEntry
+	mustDecode(t, `[
+		{"saleId":1,"transactionId":100,"date":"2026-09-10","lines":[
+			{"netPrice":1000,"vat":250,"vatType":"HIGH"},
+			{"netPrice":40,"vat":0,"vatType":"HIGH"},
+			{"netPrice":200,"vat":30,"vatType":"MEDIUM"},
+			{"netPrice":50,"vat":0,"vatType":"EXEMPT_IMPORT_EXPORT"}]},
+		{"saleId":2,"transactionId":101,"date":"2026-11-01","lines":[{"netPrice":999,"vat":249.75,"vatType":"HIGH"}]}
+	]`, &sales)
+	mustDecode(t, `[
+		{"purchaseId":10,"transactionId":200,"date":"2026-10-01","lines":[
+			{"netPrice":400,"vat":100,"vatType":"HIGH"},
+			{"netPrice":100,"vat":25,"vatType":"HIGH_FOREIGN_SERVICE_DEDUCTIBLE"},
+			{"netPrice":10,"vatType":"MYSTERY"}]}
+	]`, &purchases)
+	mustDecode(t, `[
+		{"journalEntryId":20,"transactionId":100,"date":"2026-09-10","lines":[{"amount":-1000,"account":"3000","vatCode":"3"}]},
+		{"journalEntryId":21,"transactionId":300,"date":"2026-09-15","lines":[
+			{"amount":80,"account":"6800","vatCode":"1"},
+			{"amount":20,"account":"2710"},
+			{"amount":-100,"account":"1920"}]}
+	]`, &entries)
+	vatMovements := []fiken.AccountBalance{
+		{Code: "2700", Name: "Utgående mva høy", Balance: -25000 - 2500},
+		{Code: "2701", Name: "Utgående mva middels", Balance: -3000},
+		{Code: "2710", Name: "Inngående mva høy", Balance: 10000 + 2000},
+		{Code: "2740", Name: "Oppgjørskonto", Balance: 12345},
+	}
+
+	vr := BuildVatReturn("2026-5", Period{From: "2026-09-01", To: "2026-10-31"}, sales, purchases, entries, vatMovements)
+
+	byCode := make(map[string]VatCodeLine)
+	for _, c := range vr.Codes {
+		byCode[c.Code] = c
+	}
+	// The explicit zero VAT on the 40 kr line is kept, not recomputed.
+	if c := byCode["3"]; c.Basis != 104000 || c.Vat != 25000 {
+		t.Errorf("code 3: expected basis 104000 vat 25000, got %d/%d", c.Basis, c.Vat)
+	}
+	if c := byCode["52"]; c.Basis != 5000 || c.Vat != 0 {
+		t.Errorf("code 52: expected basis 5000 vat 0, got %d/%d", c.Basis, c.Vat)
+	}
+	if c := byCode["86"]; c.Basis != 10000 || c.Vat != 2500 {
+		t.Errorf("code 86: expected basis 10000 vat 2500, got %d/%d", c.Basis, c.Vat)
+	}
+	// 40000 from the purchase plus 8000 from the journal entry.
+	if c := byCode["1"]; c.Basis != 48000 || c.Vat != 12000 {
+		t.Errorf("code 1: expected basis 48000 vat 12000, got %d/%d", c.Basis, c.Vat)
+	}
+	// Output: 25000 + 3000 + 2500 (reverse charge); deductible: 12000 + 2500.
+	if vr.OutputVat != 30500 || vr.DeductibleVat != 14500 || vr.Payable != 16000 {
+		t.Errorf("expected output 30500, deductible 14500, payable 16000, got %d/%d/%d", vr.OutputVat, vr.DeductibleVat, vr.Payable)
+	}
+	if len(vr.Unmapped) != 1 || vr.Unmapped[0].VatType != "MYSTERY" {
+		t.Errorf("expected one unmapped line, got %+v", vr.Unmapped)
+	}
+	if vr.Reconciliation.LedgerPayable != 18500 || vr.Reconciliation.Difference != -2500 || vr.Reconciliation.Matches {
+		t.Errorf("unexpected reconciliation: %+v", vr.Reconciliation)
+	}
+}
@@ -0,0 +1,69 @@
+package saft
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+	"github.com/simenandre/fiken-mcp/internal/report"
+)
+
+// Fetch collects the company, chart of accounts, balances, contacts and
+// journal entries for period from Fiken. The period must lie within one
+// fiscal year, since result accounts start each year at zero.
+func Fetch(client *fiken.Client, slug string, period report.Period) (Input, error) {
+	from, err := time.Parse("2006-01-02", period.From)
+	if err != nil {
+		return Input{}, fmt.Errorf("invalid from date %q, expected YYYY-MM-DD", period.From)
+	}
+	to, err := time.Parse("2006-01-02", period.To)
+	if err != nil {
+		return Input{}, fmt.Errorf("invalid to date %q, expected YYYY-MM-DD", period.To)
+	}
+	if to.Before(from) {
+		return Input{}, fmt.Errorf("the period ends (%s) before it starts (%s)", period.To, period.From)
+	}
+	if from.Year() != to.Year() {
+		return Input{}, fmt.Errorf("the period %s to %s spans more than one fiscal year; export one file per year", period.From, period.To)
+	}
+
+	in := Input{Period: period, Created: time.Now()}
+	if err := client.GetJSON("/companies/"+slug, nil, &in.Company); err != nil {
+		return Input{}, err
+	}
+	if err := client.GetAll("/companies/"+slug+"/accounts", nil, &in.Accounts); err != nil {
+		return Input{}, err
+	}
+
+	var opening []fiken.AccountBalance
+	balanceParams := fiken.BuildQueryParams(
+		"date", from.AddDate(0, 0, -1).Format("2006-01-02"),
+		"fromAccount", "1000",
+		"toAccount", "8999",
+	)
+	if err := client.GetAll("/companies/"+slug+"/accountBalances", balanceParams, &opening); err != nil {
+		return Input{}, err
+	}
+	for _, b := range opening {
+		// Result account balances of the previous year are not carried over.
+		if from.YearDay() > 1 || fiken.AccountNumber(b.Code) < 3000 {
+			in.Opening = append(in.Opening, b)
+		}
+	}
+	balanceParams["date"] = period.To
+	if err := client.GetAll("/companies/"+slug+"/accountBalances", balanceParams, &in.Closing); err != nil {
+		return Input{}, err
+	}
+
+	if err := client.GetAll("/companies/"+slug+"/contacts", nil, &in.Contacts); err != nil {
+		return Input{}, err
+	}
+	entryParams := fiken.BuildQueryParams(
+		"dateGe", period.From,
+		"dateLe", period.To,
+	)
+	if err := client.GetAll("/companies/"+slug+"/journalEntries", entryParams, &in.Entries); err != nil {
+		return Input{}, err
+	}
+	return in, nil
+}
@@ -0,0 +1,605 @@
+// Package saft builds Norwegian SAF-T Financial (version 1.30) audit files
+// from Fiken data.
+package saft
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+	"github.com/simenandre/fiken-mcp/internal/report"
+)
+
+const (
+	// Namespace is the XML namespace of Norwegian SAF-T Financial files.
+	Namespace = "urn:StandardAuditFile-Taxation-Financial:NO"
+	// Version is the SAF-T Financial schema version produced.
+	Version = "1.30"
+
+	softwareName    = "fiken-mcp"
+	softwareVersion = "1.0.0"
+
+	// notUsed fills mandatory fields for which Fiken has no data, as
+	// recommended in the Norwegian SAF-T documentation.
+	notUsed = "NotUsed"
+)
+
+// AuditFile is the root element of a SAF-T Financial file. Field order
+// follows the schema's element sequence.
+type AuditFile struct {
+	XMLName              xml.Name             `xml:"AuditFile"`
+	Xmlns                string               `xml:"xmlns,attr"`
+	Header               Header               `xml:"Header"`
+	MasterFiles          MasterFiles          `xml:"MasterFiles"`
+	GeneralLedgerEntries GeneralLedgerEntries `xml:"GeneralLedgerEntries"`
+}
+
+// Header identifies the company, the producing software and the selection.
+type Header struct {
+	AuditFileVersion     string            `xml:"AuditFileVersion"`
+	AuditFileCountry     string            `xml:"AuditFileCountry"`
+	AuditFileDateCreated string            `xml:"AuditFileDateCreated"`
+	SoftwareCompanyName  string            `xml:"SoftwareCompanyName"`
+	SoftwareID           string            `xml:"SoftwareID"`
+	SoftwareVersion      string            `xml:"SoftwareVersion"`
+	Company              Company           `xml:"Company"`
+	DefaultCurrencyCode  string            `xml:"DefaultCurrencyCode"`
+	SelectionCriteria    SelectionCriteria `xml:"SelectionCriteria"`
+	TaxAccountingBasis   string            `xml:"TaxAccountingBasis"`
+}
+
+// Company is the reporting company.
+type Company struct {
+	RegistrationNumber string    `xml:"RegistrationNumber"`
+	Name               string    `xml:"Name"`
+	Address            []Address `xml:"Address"`
+	Contact            []Contact `xml:"Contact"`
+}
+
+// Address is a postal address. City and PostalCode are mandatory.
+type Address struct {
+	StreetName string `xml:"StreetName,omitempty"`
+	City       string `xml:"City"`
+	PostalCode string `xml:"PostalCode"`
+	Country    string `xml:"Country,omitempty"`
+}
+
+// Contact is a contact person with optional phone and email.
+type Contact struct {
+	ContactPerson ContactPerson `xml:"ContactPerson"`
+	Telephone     string        `xml:"Telephone,omitempty"`
+	Email         string        `xml:"Email,omitempty"`
+}
+
+// ContactPerson is a person's name.
+type ContactPerson struct {
+	FirstName string `xml:"FirstName"`
+	LastName  string `xml:"LastName"`
+}
+
+// SelectionCriteria is the date range the file covers.
+type SelectionCriteria struct {
+	SelectionStartDate string `xml:"SelectionStartDate"`
+	SelectionEndDate   string `xml:"SelectionEndDate"`
+}
+
+// MasterFiles holds the chart of accounts, customers, suppliers and VAT codes.
+type MasterFiles struct {
+	GeneralLedgerAccounts *GeneralLedgerAccounts `xml:"GeneralLedgerAccounts,omitempty"`
+	Customers             *Customers             `xml:"Customers,omitempty"`
+	Suppliers             *Suppliers             `xml:"Suppliers,omitempty"`
+	TaxTable              *TaxTable              `xml:"TaxTable,omitempty"`
+}
+
+// GeneralLedgerAccounts is the chart of accounts.
+type GeneralLedgerAccounts struct {
+	Account []Account `xml:"Account"`
+}
+
+// Balances are an opening and closing balance, each given on the debit or
+// the credit side as a non-negative amount.
+type Balances struct {
+	OpeningDebitBalance  string `xml:"OpeningDebitBalance,omitempty"`
+	OpeningCreditBalance string `xml:"OpeningCreditBalance,omitempty"`
+	ClosingDebitBalance  string `xml:"ClosingDebitBalance,omitempty"`
+	ClosingCreditBalance string `xml:"ClosingCreditBalance,omitempty"`
+}
+
+func newBalances(opening, closing fiken.Amount) Balances {
+	var b Balances
+	if opening < 0 {
+		b.OpeningCreditBalance = (-opening).String()
+	} else {
+		b.OpeningDebitBalance = opening.String()
+	}
+	if closing < 0 {
+		b.ClosingCreditBalance = (-closing).String()
+	} else {
+		b.ClosingDebitBalance = closing.String()
+	}
+	return b
+}
+
+// Account is a general ledger account.
+type Account struct {
+	AccountID          string `xml:"AccountID"`
+	AccountDescription string `xml:"AccountDescription"`
+	StandardAccountID  string `xml:"StandardAccountID"`
+	AccountType        string `xml:"AccountType"`
+	Balances
+}
+
+// Customers lists the customers.
+type Customers struct {
+	Customer []Customer `xml:"Customer"`
+}
+
+// Customer is a customer with its receivable balance.
+type Customer struct {
+	RegistrationNumber string    `xml:"RegistrationNumber,omitempty"`
+	Name               string    `xml:"Name"`
+	Address            []Address `xml:"Address"`
+	CustomerID         string    `xml:"CustomerID"`
+	AccountID          string    `xml:"AccountID,omitempty"`
+	Balances
+}
+
+// Suppliers lists the suppliers.
+type Suppliers struct {
+	Supplier []Supplier `xml:"Supplier"`
+}
+
+// Supplier is a supplier with its payable balance.
+type Supplier struct {
+	RegistrationNumber string    `xml:"RegistrationNumber,omitempty"`
+	Name               string    `xml:"Name"`
+	Address            []Address `xml:"Address"`
+	SupplierID         string    `xml:"SupplierID"`
+	AccountID          string    `xml:"AccountID,omitempty"`
+	Balances
+}
+
+// TaxTable lists the VAT codes used in the file.
+type TaxTable struct {
+	TaxTableEntry []TaxTableEntry `xml:"TaxTableEntry"`
+}
+
+// TaxTableEntry groups the codes of one tax type.
+type TaxTableEntry struct {
+	TaxType        string           `xml:"TaxType"`
+	Description    string           `xml:"Description"`
+	TaxCodeDetails []TaxCodeDetails `xml:"TaxCodeDetails"`
+}
+
+// TaxCodeDetails describes one VAT code and its standard code.
+type TaxCodeDetails struct {
+	TaxCode         string `xml:"TaxCode"`
+	Description     string `xml:"Description"`
+	TaxPercentage   string `xml:"TaxPercentage"`
+	Country         string `xml:"Country"`
+	StandardTaxCode string `xml:"StandardTaxCode"`
+	BaseRate        []int  `xml:"BaseRate"`
+}
+
+// GeneralLedgerEntries holds every posted voucher in the period.
+type GeneralLedgerEntries struct {
+	NumberOfEntries int       `xml:"NumberOfEntries"`
+	TotalDebit      string    `xml:"TotalDebit"`
+	TotalCredit     string    `xml:"TotalCredit"`
+	Journal         []Journal `xml:"Journal"`
+}
+
+// Journal is a group of transactions.
+type Journal struct {
+	JournalID   string        `xml:"JournalID"`
+	Description string        `xml:"Description"`
+	Type        string        `xml:"Type"`
+	Transaction []Transaction `xml:"Transaction"`
+}
+
+// Transaction is one voucher (bilag).
+type Transaction struct {
+	TransactionID   string `xml:"TransactionID"`
+	Period          int    `xml:"Period"`
+	PeriodYear      int    `xml:"PeriodYear"`
+	TransactionDate string `xml:"TransactionDate"`
+	Description     string `xml:"Description"`
+	SystemEntryDate string `xml:"SystemEntryDate"`
+	GLPostingDate   string `xml:"GLPostingDate"`
+	Line            []Line `xml:"Line"`
+}
+
+// Line is a posting on one account.
+type Line struct {
+	RecordID       string           `xml:"RecordID"`
+	AccountID      string           `xml:"AccountID"`
+	CustomerID     string           `xml:"CustomerID,omitempty"`
+	SupplierID     string           `xml:"SupplierID,omitempty"`
+	Description    string           `xml:"Description"`
+	DebitAmount    *AmountStructure `xml:"DebitAmount,omitempty"`
+	CreditAmount   *AmountStructure `xml:"CreditAmount,omitempty"`
+	TaxInformation []TaxInformation `xml:"TaxInformation,omitempty"`
+}
+
+// AmountStructure is an amount in the default currency.
+type AmountStructure struct {
+	Amount string `xml:"Amount"`
+}
+
+// TaxInformation is the VAT treatment of a line.
+type TaxInformation struct {
+	TaxType       string          `xml:"TaxType"`
+	TaxCode       string          `xml:"TaxCode"`
+	TaxPercentage string          `xml:"TaxPercentage"`
+	TaxBase       string          `xml:"TaxBase"`
+	TaxAmount     AmountStructure `xml:"TaxAmount"`
+}
+
+// Input is the Fiken data a SAF-T file is built from.
+type Input struct {
+	Company  fiken.Company
+	Period   report.Period
+	Accounts []fiken.Account
+	// Opening and Closing are the account balances the day before the period
+	// and on its last day, including sub-ledger (customer and supplier)
+	// accounts.
+	Opening  []fiken.AccountBalance
+	Closing  []fiken.AccountBalance
+	Contacts []fiken.Contact
+	Entries  []fiken.JournalEntry
+	Created  time.Time
+}
+
+// Build assembles a SAF-T Financial audit file. Every journal entry becomes a
+// transaction in a single general ledger journal; sub-ledger postings such
+// as 1500:10001 are posted to the main account with the customer or supplier
+// ID. Accounting periods are calendar months.
+func Build(in Input) (*AuditFile, error) {
+	from, err := time.Parse("2006-01-02", in.Period.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from date %q, expected YYYY-MM-DD", in.Period.From)
+	}
+	if _, err := time.Parse("2006-01-02", in.Period.To); err != nil {
+		return nil, fmt.Errorf("invalid to date %q, expected YYYY-MM-DD", in.Period.To)
+	}
+	orgNr := strings.ReplaceAll(in.Company.OrganizationNumber, " ", "")
+	if orgNr == "" {
+		return nil, fmt.Errorf("the company has no organization number, which SAF-T requires")
+	}
+
+	f := &AuditFile{
+		Xmlns: Namespace,
+		Header: Header{
+			AuditFileVersion:     Version,
+			AuditFileCountry:     "NO",
+			AuditFileDateCreated: in.Created.Format("2006-01-02"),
+			SoftwareCompanyName:  softwareName,
+			SoftwareID:           softwareName,
+			SoftwareVersion:      softwareVersion,
+			Company: Company{
+				RegistrationNumber: orgNr,
+				Name:               in.Company.Name,
+				Address:            []Address{address(in.Company.Address, true)},
+				Contact: []Contact{{
+					ContactPerson: ContactPerson{FirstName: notUsed, LastName: notUsed},
+					Telephone:     in.Company.PhoneNumber,
+					Email:         in.Company.Email,
+				}},
+			},
+			DefaultCurrencyCode: "NOK",
+			SelectionCriteria: SelectionCriteria{
+				SelectionStartDate: in.Period.From,
+				SelectionEndDate:   in.Period.To,
+			},
+			TaxAccountingBasis: "A",
+		},
+	}
+
+	customerIDs := make(map[string]string)
+	supplierIDs := make(map[string]string)
+	for _, c := range in.Contacts {
+		if c.Customer && c.CustomerAccountCode != "" {
+			customerIDs[c.CustomerAccountCode] = partyID(c.CustomerNumber, c.ContactID)
+		}
+		if c.Supplier && c.SupplierAccountCode != "" {
+			supplierIDs[c.SupplierAccountCode] = partyID(c.SupplierNumber, c.ContactID)
+		}
+	}
+
+	usedAccounts := make(map[int]bool)
+	usedCodes := make(map[string]bool)
+	f.GeneralLedgerEntries = buildEntries(in, from.Year(), customerIDs, supplierIDs, usedAccounts, usedCodes)
+	f.MasterFiles.GeneralLedgerAccounts = buildAccounts(in, usedAccounts)
+	f.MasterFiles.Customers, f.MasterFiles.Suppliers = buildParties(in)
+	f.MasterFiles.TaxTable = buildTaxTable(usedCodes)
+	return f, nil
+}
+
+// Encode writes the audit file as indented XML with an XML declaration.
+func (f *AuditFile) Encode(w io.Writer) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(f); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func buildEntries(in Input, year int, customerIDs, supplierIDs map[string]string, usedAccounts map[int]bool, usedCodes map[string]bool) GeneralLedgerEntries {
+	entries := append([]fiken.JournalEntry(nil), in.Entries...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Date != entries[j].Date {
+			return entries[i].Date < entries[j].Date
+		}
+		return entries[i].JournalEntryNumber < entries[j].JournalEntryNumber
+	})
+
+	gl := GeneralLedgerEntries{Journal: []Journal{{JournalID: "GL", Description: "Hovedbok", Type: "GL"}}}
+	var totalDebit, totalCredit fiken.Amount
+	for _, e := range entries {
+		if !in.Period.Contains(e.Date) {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", e.Date)
+		if err != nil {
+			continue
+		}
+		description := e.Description
+		if description == "" {
+			description = fmt.Sprintf("Bilag %d", e.JournalEntryNumber)
+		}
+		id := e.JournalEntryNumber
+		if id == 0 {
+			id = e.JournalEntryID
+		}
+		tx := Transaction{
+			TransactionID:   strconv.FormatInt(id, 10),
+			Period:          int(date.Month()),
+			PeriodYear:      year,
+			TransactionDate: e.Date,
+			Description:     description,
+			SystemEntryDate: e.Date,
+			GLPostingDate:   e.Date,
+		}
+		for _, l := range e.Lines {
+			if l.Amount == 0 {
+				continue
+			}
+			n := fiken.AccountNumber(l.Account)
+			usedAccounts[n] = true
+			line := Line{
+				RecordID:    strconv.Itoa(len(tx.Line) + 1),
+				AccountID:   strconv.Itoa(n),
+				CustomerID:  customerIDs[l.Account],
+				SupplierID:  supplierIDs[l.Account],
+				Description: description,
+			}
+			if l.Amount > 0 {
+				line.DebitAmount = &AmountStructure{Amount: l.Amount.String()}
+				totalDebit += l.Amount
+			} else {
+				line.CreditAmount = &AmountStructure{Amount: (-l.Amount).String()}
+				totalCredit -= l.Amount
+			}
+			if vc, ok := fiken.VatCodes[l.VatCode]; ok {
+				usedCodes[vc.Code] = true
+				base := l.Amount
+				if base < 0 {
+					base = -base
+				}
+				line.TaxInformation = []TaxInformation{{
+					TaxType:       "MVA",
+					TaxCode:       vc.Code,
+					TaxPercentage: percent(vc.Rate),
+					TaxBase:       base.String(),
+					TaxAmount:     AmountStructure{Amount: vc.VatOn(base).String()},
+				}}
+			}
+			tx.Line = append(tx.Line, line)
+		}
+		if len(tx.Line) == 0 {
+			continue
+		}
+		gl.Journal[0].Transaction = append(gl.Journal[0].Transaction, tx)
+		gl.NumberOfEntries++
+	}
+	gl.TotalDebit = totalDebit.String()
+	gl.TotalCredit = totalCredit.String()
+	return gl
+}
+
+func buildAccounts(in Input, used map[int]bool) *GeneralLedgerAccounts {
+	names := make(map[int]string)
+	for _, b := range in.Closing {
+		if n := fiken.AccountNumber(b.Code); !strings.Contains(b.Code, ":") {
+			names[n] = b.Name
+		}
+	}
+	for _, a := range in.Accounts {
+		if !strings.Contains(a.Code, ":") {
+			names[fiken.AccountNumber(a.Code)] = a.Name
+		}
+	}
+	opening := make(map[int]fiken.Amount)
+	closing := make(map[int]fiken.Amount)
+	for _, b := range in.Opening {
+		n := fiken.AccountNumber(b.Code)
+		opening[n] += b.Balance
+		used[n] = used[n] || b.Balance != 0
+	}
+	for _, b := range in.Closing {
+		n := fiken.AccountNumber(b.Code)
+		closing[n] += b.Balance
+		used[n] = used[n] || b.Balance != 0
+	}
+
+	numbers := make([]int, 0, len(used))
+	for n, ok := range used {
+		if ok && n > 0 {
+			numbers = append(numbers, n)
+		}
+	}
+	sort.Ints(numbers)
+	if len(numbers) == 0 {
+		return nil
+	}
+	accounts := &GeneralLedgerAccounts{}
+	for _, n := range numbers {
+		id := strconv.Itoa(n)
+		name := names[n]
+		if name == "" {
+			name = id
+		}
+		standard := id
+		if len(standard) > 2 {
+			standard = standard[:2]
+		}
+		accounts.Account = append(accounts.Account, Account{
+			AccountID:          id,
+			AccountDescription: name,
+			StandardAccountID:  standard,
+			AccountType:        "GL",
+			Balances:           newBalances(opening[n], closing[n]),
+		})
+	}
+	return accounts
+}
+
+func buildParties(in Input) (*Customers, *Suppliers) {
+	opening := make(map[string]fiken.Amount)
+	closing := make(map[string]fiken.Amount)
+	for _, b := range in.Opening {
+		opening[b.Code] += b.Balance
+	}
+	for _, b := range in.Closing {
+		closing[b.Code] += b.Balance
+	}
+
+	var customers Customers
+	var suppliers Suppliers
+	for _, c := range in.Contacts {
+		orgNr := strings.ReplaceAll(c.OrganizationNumber, " ", "")
+		// Address is mandatory for customers and suppliers.
+		addresses := []Address{address(c.Address, true)}
+		if c.Customer {
+			customers.Customer = append(customers.Customer, Customer{
+				RegistrationNumber: orgNr,
+				Name:               c.Name,
+				Address:            addresses,
+				CustomerID:         partyID(c.CustomerNumber, c.ContactID),
+				AccountID:          mainAccount(c.CustomerAccountCode),
+				Balances:           newBalances(opening[c.CustomerAccountCode], closing[c.CustomerAccountCode]),
+			})
+		}
+		if c.Supplier {
+			suppliers.Supplier = append(suppliers.Supplier, Supplier{
+				RegistrationNumber: orgNr,
+				Name:               c.Name,
+				Address:            addresses,
+				SupplierID:         partyID(c.SupplierNumber, c.ContactID),
+				AccountID:          mainAccount(c.SupplierAccountCode),
+				Balances:           newBalances(opening[c.SupplierAccountCode], closing[c.SupplierAccountCode]),
+			})
+		}
+	}
+
+	var cs *Customers
+	var ss *Suppliers
+	if len(customers.Customer) > 0 {
+		cs = &customers
+	}
+	if len(suppliers.Supplier) > 0 {
+		ss = &suppliers
+	}
+	return cs, ss
+}
+
+func buildTaxTable(used map[string]bool) *TaxTable {
+	if len(used) == 0 {
+		return nil
+	}
+	codes := make([]string, 0, len(used))
+	for code := range used {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		a, _ := strconv.Atoi(codes[i])
+		b, _ := strconv.Atoi(codes[j])
+		return a < b
+	})
+	entry := TaxTableEntry{TaxType: "MVA", Description: "Merverdiavgift"}
+	for _, code := range codes {
+		vc := fiken.VatCodes[code]
+		entry.TaxCodeDetails = append(entry.TaxCodeDetails, TaxCodeDetails{
+			TaxCode:         vc.Code,
+			Description:     vc.Description,
+			TaxPercentage:   percent(vc.Rate),
+			Country:         "NO",
+			StandardTaxCode: vc.Code,
+			BaseRate:        []int{100},
+		})
+	}
+	return &TaxTable{TaxTableEntry: []TaxTableEntry{entry}}
+}
+
+var countryCode = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// address converts a Fiken address. Missing city and postal code are filled
+// with NotUsed when fill is set; the country is given as an ISO code when it
+// is one or is Norway.
+func address(a *fiken.Address, fill bool) Address {
+	var out Address
+	if a != nil {
+		out = Address{
+			StreetName: strings.TrimSpace(a.StreetAddress + " " + a.StreetAddressLine2),
+			City:       a.City,
+			PostalCode: a.PostCode,
+		}
+		switch country := strings.TrimSpace(a.Country); {
+		case country == "" || strings.EqualFold(country, "Norge") || strings.EqualFold(country, "Norway"):
+			out.Country = "NO"
+		case countryCode.MatchString(country):
+			out.Country = country
+		}
+	}
+	if fill && out.City == "" {
+		out.City = notUsed
+	}
+	if fill && out.PostalCode == "" {
+		out.PostalCode = notUsed
+	}
+	return out
+}
+
+// partyID is the customer or supplier number, or the contact ID when the
+// contact has none.
+func partyID(number, contactID int64) string {
+	if number != 0 {
+		return strconv.FormatInt(number, 10)
+	}
+	return strconv.FormatInt(contactID, 10)
+}
+
+// mainAccount returns the main account of a sub-ledger code such as
+// "1500:10001", or "" for an empty code.
+func mainAccount(code string) string {
+	if code == "" {
+		return ""
+	}
+	return strconv.Itoa(fiken.AccountNumber(code))
+}
+
+// percent formats a rate in basis points as a percentage, e.g. 1111 as 11.11.
+func percent(basisPoints int64) string {
+	return fmt.Sprintf("%d.%02d", basisPoints/100, basisPoints%100)
+}
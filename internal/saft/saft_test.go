@@ -0,0 +1,147 @@
+package saft
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+	"github.com/simenandre/fiken-mcp/internal/report"
+)
+
+// schemaPath is where the official schema from Skatteetaten
+// (github.com/Skatteetaten/saf-t) is expected. CI downloads it there; local
+// runs skip schema validation when it is missing, CI runs fail.
+const schemaPath = "testdata/Norwegian_SAF-T_Financial_Schema_v_1.30.xsd"
+
+func testInput() Input {
+	return Input{
+		Company: fiken.Company{
+			Name:               "Demo AS",
+			OrganizationNumber: "999 999 999",
+			Address:            &fiken.Address{StreetAddress: "Storgata 1", City: "Oslo", PostCode: "0155", Country: "Norge"},
+		},
+		Period:   report.Period{From: "2026-03-01", To: "2026-03-31"},
+		Accounts: []fiken.Account{{Code: "1500", Name: "Kundefordringer"}, {Code: "1920", Name: "Bank"}, {Code: "2700", Name: "Utgående mva"}, {Code: "3000", Name: "Salgsinntekt"}},
+		Opening: []fiken.AccountBalance{
+			{Code: "1920", Name: "Bank", Balance: 100000},
+			{Code: "2000", Name: "Aksjekapital", Balance: -100000},
+		},
+		Closing: []fiken.AccountBalance{
+			{Code: "1920", Name: "Bank", Balance: 100000},
+			{Code: "2000", Name: "Aksjekapital", Balance: -100000},
+			{Code: "1500:10001", Name: "Kari Nordmann", Balance: 12500},
+			{Code: "2700", Name: "Utgående mva", Balance: -2500},
+			{Code: "3000", Name: "Salgsinntekt", Balance: -10000},
+		},
+		Contacts: []fiken.Contact{
+			{ContactID: 7, Name: "Kari Nordmann", Customer: true, CustomerNumber: 10001, CustomerAccountCode: "1500:10001"},
+			{ContactID: 8, Name: "Leverandør AS", Supplier: true, SupplierAccountCode: "2400:20001", OrganizationNumber: "888888888"},
+		},
+		Entries: []fiken.JournalEntry{
+			{JournalEntryID: 1, JournalEntryNumber: 1, Date: "2026-03-02", Description: "Faktura 1", Lines: []fiken.JournalEntryLine{
+				{Account: "1500:10001", Amount: 12500},
+				{Account: "3000", Amount: -10000, VatCode: "3"},
+				{Account: "2700", Amount: -2500},
+			}},
+			{JournalEntryID: 2, JournalEntryNumber: 2, Date: "2026-04-01", Lines: []fiken.JournalEntryLine{
+				{Account: "1920", Amount: 500},
+				{Account: "3000", Amount: -500},
+			}},
+		},
+		Created: time.Date(2026, 4, 10, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestBuild(t *testing.T) {
+	f, err := Build(testInput())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if f.Header.Company.RegistrationNumber != "999999999" || f.Header.Company.Address[0].Country != "NO" {
+		t.Errorf("unexpected company header: %+v", f.Header.Company)
+	}
+	gl := f.GeneralLedgerEntries
+	if gl.NumberOfEntries != 1 || gl.TotalDebit != "125.00" || gl.TotalCredit != "125.00" {
+		t.Errorf("expected one balanced entry of 125.00, got %d entries, debit %s, credit %s", gl.NumberOfEntries, gl.TotalDebit, gl.TotalCredit)
+	}
+	lines := gl.Journal[0].Transaction[0].Line
+	if lines[0].AccountID != "1500" || lines[0].CustomerID != "10001" || lines[0].DebitAmount.Amount != "125.00" {
+		t.Errorf("unexpected receivable line: %+v", lines[0])
+	}
+	if tax := lines[1].TaxInformation; len(tax) != 1 || tax[0].TaxBase != "100.00" || tax[0].TaxAmount.Amount != "25.00" || tax[0].TaxPercentage != "25.00" {
+		t.Errorf("unexpected tax information: %+v", tax)
+	}
+
+	var ids []string
+	for _, a := range f.MasterFiles.GeneralLedgerAccounts.Account {
+		ids = append(ids, a.AccountID)
+	}
+	if got := strings.Join(ids, ","); got != "1500,1920,2000,2700,3000" {
+		t.Errorf("unexpected accounts: %s", got)
+	}
+	if c := f.MasterFiles.Customers.Customer[0]; c.AccountID != "1500" || c.ClosingDebitBalance != "125.00" || c.OpeningDebitBalance != "0.00" {
+		t.Errorf("unexpected customer: %+v", c)
+	}
+	if a := f.MasterFiles.Customers.Customer[0].Address; len(a) != 1 || a[0].City != notUsed || a[0].PostalCode != notUsed {
+		t.Errorf("expected a NotUsed address for a customer without one, got %+v", a)
+	}
+	if s := f.MasterFiles.Suppliers.Supplier[0]; s.SupplierID != "8" || s.ClosingDebitBalance != "0.00" {
+		t.Errorf("unexpected supplier: %+v", s)
+	}
+	if details := f.MasterFiles.TaxTable.TaxTableEntry[0].TaxCodeDetails; len(details) != 1 || details[0].StandardTaxCode != "3" {
+		t.Errorf("unexpected tax table: %+v", details)
+	}
+}
+
+func TestBuildRequiresOrganizationNumber(t *testing.T) {
+	in := testInput()
+	in.Company.OrganizationNumber = ""
+	if _, err := Build(in); err == nil {
+		t.Error("expected an error without an organization number")
+	}
+}
+
+func TestEncodeValidatesAgainstSchema(t *testing.T) {
+	f, err := Build(testInput())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := f.Encode(&buf); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if err := xml.Unmarshal(buf.Bytes(), new(AuditFile)); err != nil {
+		t.Fatalf("output is not well-formed XML: %v", err)
+	}
+	if !strings.Contains(buf.String(), `<AuditFile xmlns="`+Namespace+`">`) {
+		t.Errorf("expected the SAF-T namespace on the root element")
+	}
+
+	// CI is set by GitHub Actions, which downloads the schema and installs
+	// xmllint; there a missing schema is a failure.
+	skip := t.Skipf
+	if os.Getenv("CI") != "" {
+		skip = t.Fatalf
+	}
+	if _, err := os.Stat(schemaPath); err != nil {
+		skip("schema not found at %s; save the Skatteetaten schema there to validate", schemaPath)
+	}
+	xmllint, err := exec.LookPath("xmllint")
+	if err != nil {
+		skip("xmllint not installed")
+	}
+	out := filepath.Join(t.TempDir(), "saft.xml")
+	if err := os.WriteFile(out, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if msg, err := exec.Command(xmllint, "--noout", "--schema", schemaPath, out).CombinedOutput(); err != nil {
+		t.Errorf("schema validation failed: %v\n%s", err, msg)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"os"
+	"time"
 
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/simenandre/fiken-mcp/internal/fiken"
@@ -18,6 +19,13 @@ func main() {
 
 	client := fiken.NewClient(apiKey)
 
+	if len(os.Args) > 1 && os.Args[1] == "saft" {
+		if err := runSaft(client, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	s := server.NewMCPServer(
 		"fiken-mcp-server",
 		"1.0.0",
@@ -29,7 +37,24 @@ func main() {
 			"All monetary values are in NOK (Norwegian Krone)."),
 	)
 
-	tools.RegisterAll(s, client)
+	switch os.Getenv("DUPLICATE_PURCHASES") {
+	case "", tools.DuplicatePurchasesWarn, tools.DuplicatePurchasesBlock, tools.DuplicatePurchasesOff:
+	default:
+		log.Fatal("DUPLICATE_PURCHASES must be warn, block or off")
+	}
+
+	closedUntil := os.Getenv("CLOSED_UNTIL")
+	if closedUntil != "" {
+		if _, err := time.Parse("2006-01-02", closedUntil); err != nil {
+			log.Fatal("CLOSED_UNTIL must be a date in YYYY-MM-DD format")
+		}
+	}
+
+	tools.RegisterAll(s, client, tools.Config{
+		BrregBaseURL:       os.Getenv("BRREG_BASE_URL"),
+		DuplicatePurchases: os.Getenv("DUPLICATE_PURCHASES"),
+		ClosedUntil:        closedUntil,
+	})
 
 	stdio := server.NewStdioServer(s)
 	if err := stdio.Listen(context.Background(), os.Stdin, os.Stdout); err != nil {
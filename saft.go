@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+	"github.com/simenandre/fiken-mcp/internal/report"
+	"github.com/simenandre/fiken-mcp/internal/saft"
+)
+
+// runSaft implements the saft subcommand, which writes a SAF-T Financial
+// file for a period to a file or stdout.
+func runSaft(client *fiken.Client, args []string) error {
+	fs := flag.NewFlagSet("saft", flag.ContinueOnError)
+	slug := fs.String("company", "", "company slug (required)")
+	from := fs.String("from", "", "start of the period, YYYY-MM-DD (required)")
+	to := fs.String("to", "", "end of the period, YYYY-MM-DD (required)")
+	output := fs.String("o", "", "output file (default: stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *slug == "" || *from == "" || *to == "" {
+		fs.Usage()
+		return fmt.Errorf("-company, -from and -to are required")
+	}
+
+	in, err := saft.Fetch(client, *slug, report.Period{From: *from, To: *to})
+	if err != nil {
+		return err
+	}
+	f, err := saft.Build(in)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return f.Encode(os.Stdout)
+	}
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := f.Encode(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+	"github.com/simenandre/fiken-mcp/internal/report"
+)
+
+func registerAgingTools(s *server.MCPServer, client *fiken.Client) {
+	s.AddTool(
+		mcp.NewTool("get_receivables_aging",
+			mcp.WithDescription("Returns an accounts receivable aging report: invoices unsettled on the as-of date per customer, bucketed "+
+				"by days overdue (current, 1–30, 31–60, 61–90, 90+) with the exact amounts outstanding on that date, across all pages"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("as_of_date", mcp.Description("Date to report on (YYYY-MM-DD). Payments after it are treated as unpaid. Defaults to today")),
+			mcp.WithString("customer_id", mcp.Description("Only include this customer")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			asOf, err := parseAsOfDate(mcp.ExtractString(args, "as_of_date"))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			asOfDate := asOf.Format(dateLayout)
+			// Invoices settled today may have been open on an earlier date,
+			// so only today's aging can leave settled invoices out.
+			historical := asOfDate < time.Now().Format(dateLayout)
+			params := fiken.BuildQueryParams(
+				"issueDateLe", asOfDate,
+				"customerId", args["customer_id"],
+			)
+			if !historical {
+				params["settled"] = "false"
+			}
+			var invoices []fiken.Invoice
+			if err := client.GetAll("/companies/"+slug+"/invoices", params, &invoices); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			items := make([]report.OpenItem, 0, len(invoices))
+			for _, inv := range invoices {
+				// Without the sale's payments a settled invoice's past
+				// balance is unknown.
+				if inv.Settled && (!historical || inv.Sale == nil) {
+					continue
+				}
+				oi := report.OpenItem{Item: report.AgingItem{
+					ID:          inv.InvoiceID,
+					Number:      strconv.FormatInt(inv.InvoiceNumber, 10),
+					Date:        inv.IssueDate,
+					DueDate:     inv.DueDate,
+					Outstanding: inv.OutstandingOn(asOfDate),
+				}}
+				if inv.Customer != nil {
+					oi.ContactID = inv.Customer.ContactID
+					oi.Name = inv.Customer.Name
+				}
+				items = append(items, oi)
+			}
+			return jsonResult(report.BuildAging(asOf, items))
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("get_payables_aging",
+			mcp.WithDescription("Returns an accounts payable aging report: purchases unpaid on the as-of date per supplier, bucketed by days overdue "+
+				"(current, 1–30, 31–60, 61–90, 90+), plus the purchases due within the next N days (and any overdue) with "+
+				"supplier bank account number and KID, for planning a payment run"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("as_of_date", mcp.Description("Date to report on (YYYY-MM-DD). Payments after it are treated as unpaid. Defaults to today")),
+			mcp.WithNumber("due_within_days", mcp.Description("Size of the upcoming payments window in days (default: 7)")),
+			mcp.WithString("supplier_id", mcp.Description("Only include this supplier")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			asOf, err := parseAsOfDate(mcp.ExtractString(args, "as_of_date"))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			days := 7
+			if v, ok := args["due_within_days"].(float64); ok {
+				if v < 0 {
+					return mcp.NewToolResultError("due_within_days cannot be negative"), nil
+				}
+				days = int(v)
+			}
+			supplierID := mcp.ExtractString(args, "supplier_id")
+
+			params := fiken.BuildQueryParams("dateLe", asOf.Format(dateLayout))
+			var purchases []fiken.Purchase
+			if err := client.GetAll("/companies/"+slug+"/purchases", params, &purchases); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			items := make([]report.OpenItem, 0, len(purchases))
+			for _, p := range purchases {
+				// Paid purchases may have been open on the as-of date, unless
+				// they were paid when booked and have no payments to go by.
+				if p.Deleted || (p.Paid && len(p.Payments) == 0) {
+					continue
+				}
+				oi := report.OpenItem{Item: report.AgingItem{
+					ID:          p.PurchaseID,
+					Number:      p.Identifier,
+					Date:        p.Date,
+					DueDate:     p.DueDate,
+					Outstanding: p.OutstandingOn(asOf.Format(dateLayout)),
+					Kid:         p.Kid,
+				}}
+				if p.Supplier != nil {
+					oi.ContactID = p.Supplier.ContactID
+					oi.Name = p.Supplier.Name
+					oi.Item.BankAccountNumber = p.Supplier.BankAccountNumber
+				}
+				if supplierID != "" && strconv.FormatInt(oi.ContactID, 10) != supplierID {
+					continue
+				}
+				items = append(items, oi)
+			}
+
+			return jsonResult(struct {
+				report.AgingReport
+				DueWithinDays int                      `json:"dueWithinDays"`
+				Upcoming      []report.UpcomingPayment `json:"upcoming"`
+			}{
+				AgingReport:   report.BuildAging(asOf, items),
+				DueWithinDays: days,
+				Upcoming:      report.DueWithin(asOf, days, items),
+			})
+		},
+	)
+}
+
+// parseAsOfDate parses an optional YYYY-MM-DD date, defaulting to today.
+func parseAsOfDate(s string) (time.Time, error) {
+	if s == "" {
+		s = time.Now().Format(dateLayout)
+	}
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", s)
+	}
+	return t, nil
+}
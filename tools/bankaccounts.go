@@ -68,6 +68,9 @@ func registerBankAccountTools(s *server.MCPServer, client *fiken.Client) {
 			args := req.GetArguments()
 			slug := mcp.ExtractString(args, "company_slug")
 			bodyStr := mcp.ExtractString(args, "body")
+			if err := validateBankAccountBody(bodyStr); err != nil {
+				return validationErrorResult(err), nil
+			}
 			body, status, err := client.Post("/companies/"+slug+"/bankAccounts", []byte(bodyStr))
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
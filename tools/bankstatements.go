@@ -0,0 +1,262 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/simenandre/fiken-mcp/internal/bankstatement"
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func registerBankStatementTools(s *server.MCPServer, client *fiken.Client) {
+	s.AddTool(
+		mcp.NewTool("suggest_bank_matches",
+			mcp.WithDescription("Parses a bank statement (CAMT.053, OFX or Norwegian bank CSV export) and suggests, for each line, "+
+				"ranked matches among open invoices, unpaid purchases and unposted sale and purchase drafts, "+
+				"scored on amount, KID, counterparty name and date. Nothing is posted"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("content", mcp.Description("The statement file content as text")),
+			mcp.WithString("content_base64", mcp.Description("The statement file content, base64-encoded (alternative to content)")),
+			mcp.WithString("format", mcp.Description("Statement format: 'camt053', 'ofx' or 'csv'. Detected from the content when omitted")),
+			mcp.WithNumber("date_window_days", mcp.Description("Days between a line and a due date (or date) that still score on date (default: 7)")),
+			mcp.WithNumber("max_candidates", mcp.Description("Maximum candidates per line (default: 3)")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			st, err := parseStatementArgs(args)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opts := bankstatement.MatchOptions{DateWindowDays: 7, MaxCandidates: 3}
+			if v, ok := args["date_window_days"].(float64); ok && v >= 0 {
+				opts.DateWindowDays = int(v)
+			}
+			if v, ok := args["max_candidates"].(float64); ok && v > 0 {
+				opts.MaxCandidates = int(v)
+			}
+
+			candidates, err := bankMatchCandidates(client, slug, st)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			summary := st
+			summary.Lines = nil
+			return jsonResult(struct {
+				Statement bankstatement.Statement     `json:"statement"`
+				Matches   []bankstatement.LineMatches `json:"matches"`
+			}{summary, bankstatement.Match(st.Lines, candidates, opts)})
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("reconcile_bank_account",
+			mcp.WithDescription("Compares a bank account's ledger balance (its GL code in the account balances) with the balance reported "+
+				"by the bank and with an optional statement closing balance, and lists recent ledger postings not found on the statement "+
+				"that may explain the difference"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("bank_account_id", mcp.Required(), mcp.Description("The bank account ID")),
+			mcp.WithString("date", mcp.Description("Reconciliation date (YYYY-MM-DD). Defaults to today")),
+			mcp.WithString("statement_closing_balance", mcp.Description("Closing balance on the statement in NOK, e.g. '18755.50'")),
+			mcp.WithString("content", mcp.Description("Optional statement file (CAMT.053, OFX or CSV) as text; its lines are matched against postings")),
+			mcp.WithString("content_base64", mcp.Description("The statement file content, base64-encoded (alternative to content)")),
+			mcp.WithString("format", mcp.Description("Statement format: 'camt053', 'ofx' or 'csv'. Detected from the content when omitted")),
+			mcp.WithNumber("lookback_days", mcp.Description("How many days of postings before the date to examine (default: 30)")),
+			mcp.WithNumber("tolerance_days", mcp.Description("Maximum days between a posting and its statement line (default: 3)")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			date, err := parseAsOfDate(mcp.ExtractString(args, "date"))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dateStr := date.Format(dateLayout)
+			lookback, tolerance := 30, 3
+			if v, ok := args["lookback_days"].(float64); ok && v >= 0 {
+				lookback = int(v)
+			}
+			if v, ok := args["tolerance_days"].(float64); ok && v >= 0 {
+				tolerance = int(v)
+			}
+
+			var statementBalance *fiken.Amount
+			if v := mcp.ExtractString(args, "statement_closing_balance"); v != "" {
+				amount, err := fiken.ParseAmount(v)
+				if err != nil {
+					return mcp.NewToolResultError("statement_closing_balance: " + err.Error()), nil
+				}
+				statementBalance = &amount
+			}
+			var lines []bankstatement.Line
+			if mcp.ExtractString(args, "content") != "" || mcp.ExtractString(args, "content_base64") != "" {
+				st, err := parseStatementArgs(args)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				lines = st.Lines
+				if statementBalance == nil {
+					statementBalance = st.ClosingBalance
+				}
+			}
+
+			var account fiken.BankAccount
+			if err := client.GetJSON("/companies/"+slug+"/bankAccounts/"+mcp.ExtractString(args, "bank_account_id"), nil, &account); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if account.AccountCode == "" {
+				return mcp.NewToolResultError("the bank account has no ledger account code"), nil
+			}
+			var ledger fiken.AccountBalance
+			if err := client.GetJSON("/companies/"+slug+"/accountBalances/"+account.AccountCode, fiken.BuildQueryParams("date", dateStr), &ledger); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var bankBalance *fiken.Amount
+			var balances []fiken.BankBalance
+			if err := client.GetAll("/companies/"+slug+"/bankBalances", fiken.BuildQueryParams("date", dateStr), &balances); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for _, b := range balances {
+				if b.BankAccountID == account.BankAccountID || b.BankAccountCode == account.AccountCode {
+					balance := b.Balance
+					bankBalance = &balance
+					break
+				}
+			}
+
+			if bankBalance == nil && statementBalance == nil {
+				return mcp.NewToolResultError("nothing to reconcile against: Fiken reports no bank balance for the account on " + dateStr +
+					"; give statement_closing_balance or a statement with a closing balance"), nil
+			}
+
+			params := fiken.BuildQueryParams(
+				"dateGe", date.AddDate(0, 0, -lookback).Format(dateLayout),
+				"dateLe", dateStr,
+			)
+			var entries []fiken.JournalEntry
+			if err := client.GetAll("/companies/"+slug+"/journalEntries", params, &entries); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var postings []bankstatement.Posting
+			for _, e := range entries {
+				for _, l := range e.Lines {
+					if l.Account == account.AccountCode && l.Amount != 0 {
+						postings = append(postings, bankstatement.Posting{
+							JournalEntryID:     e.JournalEntryID,
+							JournalEntryNumber: e.JournalEntryNumber,
+							Date:               e.Date,
+							Description:        e.Description,
+							Amount:             l.Amount,
+						})
+					}
+				}
+			}
+
+			return jsonResult(bankstatement.Reconcile(account.AccountCode, dateStr, ledger.Balance, bankBalance, statementBalance, postings, lines, tolerance))
+		},
+	)
+}
+
+// parseStatementArgs parses the statement given as content or content_base64.
+func parseStatementArgs(args map[string]interface{}) (bankstatement.Statement, error) {
+	data := []byte(mcp.ExtractString(args, "content"))
+	if encoded := mcp.ExtractString(args, "content_base64"); encoded != "" {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return bankstatement.Statement{}, fmt.Errorf("invalid base64 content: %v", err)
+		}
+		data = decoded
+	}
+	if len(data) == 0 {
+		return bankstatement.Statement{}, fmt.Errorf("either content or content_base64 is required")
+	}
+	return bankstatement.Parse(data, mcp.ExtractString(args, "format"))
+}
+
+// bankMatchCandidates collects open invoices, unpaid purchases and unposted
+// sale and purchase drafts.
+func bankMatchCandidates(client *fiken.Client, slug string, st bankstatement.Statement) ([]bankstatement.Candidate, error) {
+	var candidates []bankstatement.Candidate
+
+	var invoices []fiken.Invoice
+	if err := client.GetAll("/companies/"+slug+"/invoices", fiken.BuildQueryParams("settled", "false"), &invoices); err != nil {
+		return nil, err
+	}
+	for _, inv := range invoices {
+		if inv.Settled {
+			continue
+		}
+		c := bankstatement.Candidate{
+			Type:    bankstatement.CandidateInvoice,
+			ID:      inv.InvoiceID,
+			Number:  strconv.FormatInt(inv.InvoiceNumber, 10),
+			Date:    inv.IssueDate,
+			DueDate: inv.DueDate,
+			Amount:  inv.Outstanding(),
+			KID:     inv.Kid,
+		}
+		if inv.Customer != nil {
+			c.Counterparty = inv.Customer.Name
+		}
+		candidates = append(candidates, c)
+	}
+
+	var purchases []fiken.Purchase
+	if err := client.GetAll("/companies/"+slug+"/purchases", fiken.BuildQueryParams("dateLe", st.To), &purchases); err != nil {
+		return nil, err
+	}
+	for _, p := range purchases {
+		if p.Paid || p.Deleted {
+			continue
+		}
+		c := bankstatement.Candidate{
+			Type:    bankstatement.CandidatePurchase,
+			ID:      p.PurchaseID,
+			Number:  p.Identifier,
+			Date:    p.Date,
+			DueDate: p.DueDate,
+			Amount:  -p.Outstanding(),
+			KID:     p.Kid,
+		}
+		if p.Supplier != nil {
+			c.Counterparty = p.Supplier.Name
+		}
+		candidates = append(candidates, c)
+	}
+
+	for _, d := range []struct {
+		resource, candidateType string
+		sign                    fiken.Amount
+	}{
+		{"sales", bankstatement.CandidateSaleDraft, 1},
+		{"purchases", bankstatement.CandidatePurchaseDraft, -1},
+	} {
+		var drafts []fiken.Draft
+		if err := client.GetAll("/companies/"+slug+"/"+d.resource+"/drafts", nil, &drafts); err != nil {
+			return nil, err
+		}
+		for _, draft := range drafts {
+			c := bankstatement.Candidate{
+				Type:    d.candidateType,
+				ID:      draft.DraftID,
+				Number:  draft.InvoiceNumber,
+				Date:    draft.InvoiceIssueDate,
+				DueDate: draft.DueDate,
+				Amount:  d.sign * draft.Gross(),
+				KID:     draft.Kid,
+			}
+			if len(draft.Contacts) > 0 {
+				c.Counterparty = draft.Contacts[0].Name
+			}
+			if c.Amount != 0 {
+				candidates = append(candidates, c)
+			}
+		}
+	}
+	return candidates, nil
+}
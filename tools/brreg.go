@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/simenandre/fiken-mcp/internal/brreg"
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func registerBrregTools(s *server.MCPServer, client *fiken.Client, registry *brreg.Client) {
+	s.AddTool(
+		mcp.NewTool("lookup_organization",
+			mcp.WithDescription("Looks up an organization number in Enhetsregisteret (the Brønnøysund register) and returns its name, "+
+				"address, VAT registration and status, with a contact body pre-filled for create_contact. When contact_id is "+
+				"given, the body is the existing contact updated with the registered name and address, for update_contact, "+
+				"and the changed fields are listed. Nothing is sent to Fiken"),
+			mcp.WithString("organization_number", mcp.Required(), mcp.Description("The 9-digit organization number")),
+			mcp.WithString("company_slug", mcp.Description("The company slug identifier; required with contact_id")),
+			mcp.WithString("contact_id", mcp.Description("Existing contact to prepare an update for")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			entity, err := registry.Lookup(mcp.ExtractString(args, "organization_number"))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			warnings := []string{}
+			switch {
+			case entity.Deleted:
+				warnings = append(warnings, "the organization is deleted from Enhetsregisteret")
+			case entity.Bankrupt:
+				warnings = append(warnings, "the organization is in bankruptcy")
+			case entity.UnderLiquidation:
+				warnings = append(warnings, "the organization is being wound up")
+			}
+			if !entity.VatRegistered {
+				warnings = append(warnings, "the organization is not VAT registered; purchases from it should not carry deductible VAT")
+			}
+			if entity.SubUnit {
+				warnings = append(warnings, fmt.Sprintf("this is a sub-unit; the legal entity is %s", entity.ParentOrganizationNumber))
+			}
+
+			contact := map[string]interface{}{}
+			var changes []string
+			if id := mcp.ExtractString(args, "contact_id"); id != "" {
+				slug := mcp.ExtractString(args, "company_slug")
+				if slug == "" {
+					return mcp.NewToolResultError("company_slug is required with contact_id"), nil
+				}
+				body, status, err := client.Get("/companies/"+slug+"/contacts/"+id, nil)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if status >= 400 {
+					return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+				}
+				if err := json.Unmarshal(body, &contact); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("decoding contact: %v", err)), nil
+				}
+			}
+			changes = setContactField(contact, "name", entity.Name, changes)
+			changes = setContactField(contact, "organizationNumber", entity.OrganizationNumber, changes)
+			if a := entity.Address(); a != nil {
+				changes = setContactField(contact, "address", a, changes)
+			}
+
+			return jsonResult(struct {
+				Entity   *brreg.Entity          `json:"entity"`
+				Contact  map[string]interface{} `json:"contact"`
+				Changes  []string               `json:"changes,omitempty"`
+				Warnings []string               `json:"warnings"`
+			}{entity, contact, changes, warnings})
+		},
+	)
+}
+
+// setContactField sets key in a contact body to value as JSON and records
+// the key in changes when it differs from the value already there.
+func setContactField(contact map[string]interface{}, key string, value interface{}, changes []string) []string {
+	var normalized interface{}
+	b, _ := json.Marshal(value)
+	_ = json.Unmarshal(b, &normalized)
+	old, existed := contact[key]
+	contact[key] = normalized
+	if existed {
+		before, _ := json.Marshal(old)
+		after, _ := json.Marshal(normalized)
+		if string(before) != string(after) {
+			changes = append(changes, key)
+		}
+	}
+	return changes
+}
@@ -0,0 +1,45 @@
+package tools
+
+import (
+	"sync"
+	"time"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// chartTTL is how long a company's chart of accounts is reused before it is
+// fetched again.
+const chartTTL = 10 * time.Minute
+
+// chartCache caches each company's chart of accounts, so validating a
+// journal entry does not fetch it every time.
+type chartCache struct {
+	client *fiken.Client
+	mu     sync.Mutex
+	charts map[string]cachedChart
+}
+
+type cachedChart struct {
+	accounts []fiken.Account
+	fetched  time.Time
+}
+
+func newChartCache(client *fiken.Client) *chartCache {
+	return &chartCache{client: client, charts: map[string]cachedChart{}}
+}
+
+// accounts returns the company's accounts, fetching them when the cached
+// chart is missing or older than chartTTL.
+func (c *chartCache) accounts(slug string) ([]fiken.Account, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.charts[slug]; ok && time.Since(cached.fetched) < chartTTL {
+		return cached.accounts, nil
+	}
+	var accounts []fiken.Account
+	if err := c.client.GetAll("/companies/"+slug+"/accounts", nil, &accounts); err != nil {
+		return nil, err
+	}
+	c.charts[slug] = cachedChart{accounts: accounts, fetched: time.Now()}
+	return accounts, nil
+}
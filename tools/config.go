@@ -0,0 +1,16 @@
+package tools
+
+// Config holds settings that are not part of a tool call.
+type Config struct {
+	// BrregBaseURL is the base URL of the Enhetsregisteret API. Empty means
+	// the public API.
+	BrregBaseURL string
+	// DuplicatePurchases is what create_purchase and
+	// create_purchase_from_draft do when the purchase looks like one already
+	// booked: "warn" (the default) books it and reports the match, "block"
+	// refuses unless allow_duplicate is set, and "off" skips the check.
+	DuplicatePurchases string
+	// ClosedUntil is the last date (YYYY-MM-DD) of the closed accounting
+	// period; journal entries dated on or before it are rejected.
+	ClosedUntil string
+}
@@ -2,7 +2,10 @@ package tools
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -23,6 +26,7 @@ func registerContactTools(s *server.MCPServer, client *fiken.Client) {
 			mcp.WithString("supplier_number", mcp.Description("Filter by supplier number")),
 			mcp.WithString("customer_number", mcp.Description("Filter by customer number")),
 			mcp.WithString("group", mcp.Description("Filter by group")),
+			mcp.WithString("inactive", mcp.Description("'true' to return inactive contacts, 'false' for active")),
 			mcp.WithString("last_modified", mcp.Description("Filter by last modified date (YYYY-MM-DD)")),
 			mcp.WithString("last_modified_le", mcp.Description("Filter: last modified ≤ date")),
 			mcp.WithString("last_modified_lt", mcp.Description("Filter: last modified < date")),
@@ -42,6 +46,7 @@ func registerContactTools(s *server.MCPServer, client *fiken.Client) {
 				"supplierNumber", args["supplier_number"],
 				"customerNumber", args["customer_number"],
 				"group", args["group"],
+				"inactive", args["inactive"],
 				"lastModified", args["last_modified"],
 				"lastModifiedLe", args["last_modified_le"],
 				"lastModifiedLt", args["last_modified_lt"],
@@ -90,6 +95,9 @@ func registerContactTools(s *server.MCPServer, client *fiken.Client) {
 			args := req.GetArguments()
 			slug := mcp.ExtractString(args, "company_slug")
 			bodyStr := mcp.ExtractString(args, "body")
+			if err := validateContactBody(bodyStr); err != nil {
+				return validationErrorResult(err), nil
+			}
 			body, status, err := client.Post("/companies/"+slug+"/contacts", []byte(bodyStr))
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -113,6 +121,9 @@ func registerContactTools(s *server.MCPServer, client *fiken.Client) {
 			slug := mcp.ExtractString(args, "company_slug")
 			id := mcp.ExtractString(args, "contact_id")
 			bodyStr := mcp.ExtractString(args, "body")
+			if err := validateContactBody(bodyStr); err != nil {
+				return validationErrorResult(err), nil
+			}
 			body, status, err := client.Put("/companies/"+slug+"/contacts/"+id, []byte(bodyStr))
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -147,4 +158,355 @@ func registerContactTools(s *server.MCPServer, client *fiken.Client) {
 			return mcp.NewToolResultText(fmt.Sprintf("Contact %s deleted successfully", id)), nil
 		},
 	)
+
+	// Contact Persons
+	s.AddTool(
+		mcp.NewTool("get_contact_persons",
+			mcp.WithDescription("Retrieves all contact persons for a contact"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("contact_id", mcp.Required(), mcp.Description("The contact ID")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "contact_id")
+			body, status, err := client.Get("/companies/"+slug+"/contacts/"+id+"/contactPerson", nil)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(string(body)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("get_contact_person",
+			mcp.WithDescription("Retrieves a specific contact person for a contact"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("contact_id", mcp.Required(), mcp.Description("The contact ID")),
+			mcp.WithString("contact_person_id", mcp.Required(), mcp.Description("The contact person ID")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "contact_id")
+			personID := mcp.ExtractString(args, "contact_person_id")
+			body, status, err := client.Get("/companies/"+slug+"/contacts/"+id+"/contactPerson/"+personID, nil)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(string(body)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("create_contact_person",
+			mcp.WithDescription("Adds a new contact person to a contact"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("contact_id", mcp.Required(), mcp.Description("The contact ID")),
+			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with contact person details (name, email, phoneNumber, address)")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "contact_id")
+			bodyStr := mcp.ExtractString(args, "body")
+			body, status, err := client.Post("/companies/"+slug+"/contacts/"+id+"/contactPerson", []byte(bodyStr))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(string(body)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("update_contact_person",
+			mcp.WithDescription("Updates an existing contact person"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("contact_id", mcp.Required(), mcp.Description("The contact ID")),
+			mcp.WithString("contact_person_id", mcp.Required(), mcp.Description("The contact person ID")),
+			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with contact person fields to update")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "contact_id")
+			personID := mcp.ExtractString(args, "contact_person_id")
+			bodyStr := mcp.ExtractString(args, "body")
+			body, status, err := client.Put("/companies/"+slug+"/contacts/"+id+"/contactPerson/"+personID, []byte(bodyStr))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(string(body)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("delete_contact_person",
+			mcp.WithDescription("Deletes a contact person from a contact"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("contact_id", mcp.Required(), mcp.Description("The contact ID")),
+			mcp.WithString("contact_person_id", mcp.Required(), mcp.Description("The contact person ID")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "contact_id")
+			personID := mcp.ExtractString(args, "contact_person_id")
+			body, status, err := client.Delete("/companies/" + slug + "/contacts/" + id + "/contactPerson/" + personID)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Contact person %s deleted successfully", personID)), nil
+		},
+	)
+
+	// Contact Attachments
+	s.AddTool(
+		mcp.NewTool("add_contact_attachment",
+			mcp.WithDescription("Uploads an attachment (e.g. a signed agreement) to a contact"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("contact_id", mcp.Required(), mcp.Description("The contact ID")),
+			mcp.WithString("filename", mcp.Required(), mcp.Description("File name including extension, e.g. 'agreement.pdf'")),
+			mcp.WithString("content_base64", mcp.Required(), mcp.Description("The file content, base64-encoded")),
+			mcp.WithString("comment", mcp.Description("Optional comment for the attachment")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "contact_id")
+			filename := mcp.ExtractString(args, "filename")
+			content, err := base64.StdEncoding.DecodeString(mcp.ExtractString(args, "content_base64"))
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid base64 content: %v", err)), nil
+			}
+			fields := fiken.BuildQueryParams(
+				"filename", filename,
+				"comment", args["comment"],
+			)
+			body, status, err := client.PostMultipart("/companies/"+slug+"/contacts/"+id+"/attachments", fields, filename, content)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			if len(body) > 0 {
+				return mcp.NewToolResultText(string(body)), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Attachment %s added to contact %s", filename, id)), nil
+		},
+	)
+
+	// Contact Groups
+	s.AddTool(
+		mcp.NewTool("get_contact_groups",
+			mcp.WithDescription("Returns the names of all contact groups in a company"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			body, status, err := client.Get("/companies/"+slug+"/groups", nil)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(string(body)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("add_contacts_to_group",
+			mcp.WithDescription("Adds one or more contacts to a contact group, creating the group if it does not exist"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("group", mcp.Required(), mcp.Description("The group name, e.g. 'EHF customers'")),
+			mcp.WithString("contact_ids", mcp.Required(), mcp.Description("Comma-separated list of contact IDs")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			group := mcp.ExtractString(args, "group")
+			ids := splitList(mcp.ExtractString(args, "contact_ids"))
+			if group == "" || len(ids) == 0 {
+				return mcp.NewToolResultError("group and contact_ids are required"), nil
+			}
+			return jsonResult(setContactGroup(client, slug, ids, group, true))
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("remove_contacts_from_group",
+			mcp.WithDescription("Removes one or more contacts from a contact group"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("group", mcp.Required(), mcp.Description("The group name")),
+			mcp.WithString("contact_ids", mcp.Required(), mcp.Description("Comma-separated list of contact IDs")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			group := mcp.ExtractString(args, "group")
+			ids := splitList(mcp.ExtractString(args, "contact_ids"))
+			if group == "" || len(ids) == 0 {
+				return mcp.NewToolResultError("group and contact_ids are required"), nil
+			}
+			return jsonResult(setContactGroup(client, slug, ids, group, false))
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("get_contact_segment",
+			mcp.WithDescription("Returns all contacts (across all pages) matching customer/supplier/inactive flags and an optional group"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("customer", mcp.Description("'true' for customers only, 'false' to exclude customers")),
+			mcp.WithString("supplier", mcp.Description("'true' for suppliers only, 'false' to exclude suppliers")),
+			mcp.WithString("inactive", mcp.Description("'true' for inactive contacts only, 'false' or omitted for active only")),
+			mcp.WithString("group", mcp.Description("Only contacts in this group")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			// Fiken returns active contacts unless inactive is set.
+			params := fiken.BuildQueryParams(
+				"group", args["group"],
+				"inactive", args["inactive"],
+			)
+			var contacts []map[string]interface{}
+			if err := client.GetAll("/companies/"+slug+"/contacts", params, &contacts); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			flags := map[string]string{
+				"customer": mcp.ExtractString(args, "customer"),
+				"supplier": mcp.ExtractString(args, "supplier"),
+				"inactive": mcp.ExtractString(args, "inactive"),
+			}
+			segment := make([]map[string]interface{}, 0, len(contacts))
+			for _, c := range contacts {
+				if matchesFlags(c, flags) {
+					segment = append(segment, c)
+				}
+			}
+			return jsonResult(segment)
+		},
+	)
+}
+
+// contactGroupResult reports the outcome of a group change for one contact.
+type contactGroupResult struct {
+	ContactID string `json:"contactId"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
+
+// readOnlyContactFields are returned by GET but must not be sent back on PUT.
+var readOnlyContactFields = []string{"contactId", "createdDate", "lastModifiedDate", "documents", "contactPerson"}
+
+// setContactGroup adds (or removes) group on each contact. Fiken has no group
+// endpoint for this, so every contact is fetched, its groups list edited and
+// the contact written back. Failures are reported per contact.
+func setContactGroup(client *fiken.Client, slug string, ids []string, group string, add bool) []contactGroupResult {
+	results := make([]contactGroupResult, 0, len(ids))
+	for _, id := range ids {
+		result := contactGroupResult{ContactID: id}
+		path := "/companies/" + slug + "/contacts/" + id
+
+		var contact map[string]interface{}
+		if err := client.GetJSON(path, nil, &contact); err != nil {
+			result.Status = "failed"
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		var groups []interface{}
+		found := false
+		existing, _ := contact["groups"].([]interface{})
+		for _, g := range existing {
+			if g == group {
+				found = true
+				if !add {
+					continue
+				}
+			}
+			groups = append(groups, g)
+		}
+		if found == add {
+			result.Status = "unchanged"
+			results = append(results, result)
+			continue
+		}
+		if add {
+			groups = append(groups, group)
+		}
+		if groups == nil {
+			groups = []interface{}{}
+		}
+		contact["groups"] = groups
+		for _, f := range readOnlyContactFields {
+			delete(contact, f)
+		}
+
+		reqBody, err := json.Marshal(contact)
+		if err != nil {
+			result.Status = "failed"
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+		body, status, err := client.Put(path, reqBody)
+		switch {
+		case err != nil:
+			result.Status = "failed"
+			result.Error = err.Error()
+		case status >= 400:
+			result.Status = "failed"
+			result.Error = fmt.Sprintf("API error %d: %s", status, string(body))
+		default:
+			result.Status = "updated"
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// matchesFlags reports whether the contact's boolean fields match every flag
+// given as 'true' or 'false'. Empty flags are ignored.
+func matchesFlags(contact map[string]interface{}, flags map[string]string) bool {
+	for field, want := range flags {
+		if want == "" {
+			continue
+		}
+		got, _ := contact[field].(bool)
+		if got != (want == "true") {
+			return false
+		}
+	}
+	return true
+}
+
+// splitList splits a comma-separated argument into trimmed, non-empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
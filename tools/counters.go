@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func registerCounterTools(s *server.MCPServer, client *fiken.Client) {
+	addCounterTools(s, client, "invoice", "invoices")
+	addCounterTools(s, client, "credit_note", "creditNotes")
+	addCounterTools(s, client, "offer", "offers")
+	addCounterTools(s, client, "order_confirmation", "orderConfirmations")
+}
+
+// addCounterTools registers the get_<name>_counter and create_<name>_counter
+// tools for a document type. Fiken requires the counter to be created once
+// per company before the first document of that type can be made.
+func addCounterTools(s *server.MCPServer, client *fiken.Client, name, resource string) {
+	label := strings.ReplaceAll(name, "_", " ")
+
+	s.AddTool(
+		mcp.NewTool("get_"+name+"_counter",
+			mcp.WithDescription(fmt.Sprintf("Returns the current %s counter (the number of the last %s created)", label, label)),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			body, status, err := client.Get("/companies/"+slug+"/"+resource+"/counter", nil)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(string(body)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("create_"+name+"_counter",
+			mcp.WithDescription(fmt.Sprintf("Initializes the %s counter. Must be done once before the first %s can be created", label, label)),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithNumber("value", mcp.Description(fmt.Sprintf("Counter start value; the first %s gets value + 1. Defaults to Fiken's start value", label))),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			var reqBody []byte
+			if v, ok := args["value"].(float64); ok {
+				reqBody, _ = json.Marshal(map[string]int64{"value": int64(v)})
+			}
+			body, status, err := client.Post("/companies/"+slug+"/"+resource+"/counter", reqBody)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			if len(body) > 0 {
+				return mcp.NewToolResultText(string(body)), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("The %s counter was initialized", label)), nil
+		},
+	)
+}
+
+// counterErrorResult builds the tool error for a failed document creation.
+// When Fiken rejects the request and the document counter does not exist
+// (Fiken answers 404 for it), the generic error is replaced by a message
+// naming the tool that fixes it; other errors are returned unchanged.
+func counterErrorResult(client *fiken.Client, slug, resource, name string, status int, body []byte) *mcp.CallToolResult {
+	apiErr := fmt.Sprintf("API error %d: %s", status, string(body))
+	if status < 400 || status >= 500 {
+		return mcp.NewToolResultError(apiErr)
+	}
+	if _, counterStatus, err := client.Get("/companies/"+slug+"/"+resource+"/counter", nil); err != nil || counterStatus != http.StatusNotFound {
+		return mcp.NewToolResultError(apiErr)
+	}
+	label := strings.ReplaceAll(name, "_", " ")
+	return mcp.NewToolResultError(fmt.Sprintf(
+		"The %s counter has not been initialized for this company, so Fiken cannot number the document. "+
+			"Call create_%s_counter (optionally with the start value) and then retry. (%s)",
+		label, name, apiErr))
+}
@@ -0,0 +1,174 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/simenandre/fiken-mcp/internal/duplicates"
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func registerDuplicateTools(s *server.MCPServer, client *fiken.Client) {
+	s.AddTool(
+		mcp.NewTool("find_duplicate_contacts",
+			mcp.WithDescription("Finds likely duplicate contacts: contacts sharing an organization number, normalized name (ignoring "+
+				"case, punctuation and company forms such as AS), email domain (except free mail providers) or phone number are "+
+				"clustered. Each contact shows how many invoices, sales and purchases it has, and each cluster suggests the "+
+				"contact to keep. Nothing is merged or changed"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("include_inactive", mcp.Description("Set to 'true' to include inactive contacts")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			includeInactive := mcp.ExtractString(args, "include_inactive") == "true"
+
+			// Fiken returns active contacts unless inactive is set, so inactive
+			// contacts are fetched separately.
+			var contacts []fiken.Contact
+			if err := client.GetAll("/companies/"+slug+"/contacts", nil, &contacts); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if includeInactive {
+				var inactive []fiken.Contact
+				if err := client.GetAll("/companies/"+slug+"/contacts", fiken.BuildQueryParams("inactive", "true"), &inactive); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				contacts = append(contacts, inactive...)
+			}
+
+			usage := map[int64]duplicates.ContactUsage{}
+			var invoices []fiken.Invoice
+			if err := client.GetAll("/companies/"+slug+"/invoices", nil, &invoices); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for _, inv := range invoices {
+				if inv.Customer != nil {
+					u := usage[inv.Customer.ContactID]
+					u.Invoices++
+					usage[inv.Customer.ContactID] = u
+				}
+			}
+			var sales []fiken.Sale
+			if err := client.GetAll("/companies/"+slug+"/sales", nil, &sales); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for _, sale := range sales {
+				if sale.Customer != nil && !sale.Deleted {
+					u := usage[sale.Customer.ContactID]
+					u.Sales++
+					usage[sale.Customer.ContactID] = u
+				}
+			}
+			var purchases []fiken.Purchase
+			if err := client.GetAll("/companies/"+slug+"/purchases", nil, &purchases); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for _, p := range purchases {
+				if p.Supplier != nil && !p.Deleted {
+					u := usage[p.Supplier.ContactID]
+					u.Purchases++
+					usage[p.Supplier.ContactID] = u
+				}
+			}
+
+			clusters := duplicates.FindContactDuplicates(contacts, usage)
+			if clusters == nil {
+				clusters = []duplicates.ContactCluster{}
+			}
+			return jsonResult(struct {
+				ContactsChecked int                         `json:"contactsChecked"`
+				Clusters        []duplicates.ContactCluster `json:"clusters"`
+			}{len(contacts), clusters})
+		},
+	)
+}
+
+// Duplicate purchase policies for Config.DuplicatePurchases.
+const (
+	DuplicatePurchasesWarn  = "warn"
+	DuplicatePurchasesBlock = "block"
+	DuplicatePurchasesOff   = "off"
+)
+
+// purchaseBody decodes the fields of a purchase request body that identify
+// the supplier invoice. Amounts in the body are in NOK.
+func purchaseBody(body string) (duplicates.NewPurchase, bool) {
+	var p struct {
+		Identifier string            `json:"identifier"`
+		Date       string            `json:"date"`
+		SupplierID int64             `json:"supplierId"`
+		Lines      []fiken.OrderLine `json:"lines"`
+	}
+	if err := json.Unmarshal([]byte(body), &p); err != nil || p.SupplierID == 0 {
+		return duplicates.NewPurchase{}, false
+	}
+	np := duplicates.NewPurchase{SupplierID: p.SupplierID, Identifier: p.Identifier, Date: p.Date}
+	for _, l := range p.Lines {
+		np.Gross += l.NetPrice + l.Vat
+	}
+	return np, true
+}
+
+// purchaseDraftPurchase fetches a purchase draft and returns the purchase it
+// would create.
+func purchaseDraftPurchase(client *fiken.Client, slug, draftID string) (duplicates.NewPurchase, bool, error) {
+	var d struct {
+		InvoiceNumber    string `json:"invoiceNumber"`
+		InvoiceIssueDate string `json:"invoiceIssueDate"`
+		Contacts         []struct {
+			ContactID int64 `json:"contactId"`
+		} `json:"contacts"`
+		Lines []struct {
+			Gross fiken.Amount `json:"gross"`
+		} `json:"lines"`
+	}
+	if err := client.GetJSON("/companies/"+slug+"/purchases/drafts/"+draftID, nil, &d); err != nil {
+		return duplicates.NewPurchase{}, false, err
+	}
+	if len(d.Contacts) == 0 {
+		return duplicates.NewPurchase{}, false, nil
+	}
+	np := duplicates.NewPurchase{SupplierID: d.Contacts[0].ContactID, Identifier: d.InvoiceNumber, Date: d.InvoiceIssueDate}
+	for _, l := range d.Lines {
+		np.Gross += l.Gross
+	}
+	return np, true, nil
+}
+
+// checkDuplicatePurchase looks for existing purchases like p. It returns an
+// error result when the policy blocks the purchase, and otherwise the matches
+// to report after booking.
+func checkDuplicatePurchase(client *fiken.Client, slug string, p duplicates.NewPurchase, policy string, allow bool) ([]duplicates.PurchaseMatch, *mcp.CallToolResult) {
+	var existing []fiken.Purchase
+	if err := client.GetAll("/companies/"+slug+"/purchases", nil, &existing); err != nil {
+		return nil, mcp.NewToolResultError("checking for duplicate purchases: " + err.Error())
+	}
+	matches := duplicates.FindPurchaseDuplicates(p, existing)
+	if len(matches) > 0 && policy == DuplicatePurchasesBlock && !allow {
+		out, _ := json.Marshal(matches)
+		return nil, mcp.NewToolResultError(fmt.Sprintf("possible duplicate purchase, not booked: %s. "+
+			"Set allow_duplicate to 'true' if this is a different invoice", out))
+	}
+	return matches, nil
+}
+
+// duplicateWarningResult returns the Fiken response with the suspected
+// duplicates, or the response alone when there are none.
+func duplicateWarningResult(body []byte, matches []duplicates.PurchaseMatch) (*mcp.CallToolResult, error) {
+	if len(matches) == 0 {
+		return mcp.NewToolResultText(string(body)), nil
+	}
+	result := json.RawMessage("null")
+	if json.Valid(body) {
+		result = body
+	}
+	return jsonResult(struct {
+		Result             json.RawMessage            `json:"result"`
+		Warning            string                     `json:"warning"`
+		PossibleDuplicates []duplicates.PurchaseMatch `json:"possibleDuplicates"`
+	}{result, "the purchase was booked, but matches existing purchases from the same supplier", matches})
+}
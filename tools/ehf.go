@@ -0,0 +1,244 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/simenandre/fiken-mcp/internal/ehf"
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// purchaseDraftLine is a line in a purchase draft request.
+type purchaseDraftLine struct {
+	Text    string       `json:"text"`
+	VatType string       `json:"vatType"`
+	Account string       `json:"account"`
+	Net     fiken.Amount `json:"net"`
+	Gross   fiken.Amount `json:"gross"`
+}
+
+// purchaseDraft is the body of a purchase draft request.
+type purchaseDraft struct {
+	Cash             bool                `json:"cash"`
+	InvoiceIssueDate string              `json:"invoiceIssueDate"`
+	DueDate          string              `json:"dueDate,omitempty"`
+	InvoiceNumber    string              `json:"invoiceNumber,omitempty"`
+	Kid              string              `json:"kid,omitempty"`
+	ContactID        int64               `json:"contactId"`
+	ProjectID        int64               `json:"projectId,omitempty"`
+	Currency         string              `json:"currency,omitempty"`
+	Lines            []purchaseDraftLine `json:"lines"`
+}
+
+func registerEhfTools(s *server.MCPServer, client *fiken.Client) {
+	s.AddTool(
+		mcp.NewTool("create_purchase_draft_from_ehf",
+			mcp.WithDescription("Parses an EHF (PEPPOL BIS Billing 3.0) invoice or credit note and creates a purchase draft from it: "+
+				"the supplier is matched on organization number, lines keep their text, amounts and VAT category, and the original "+
+				"XML is attached to the draft. Lines whose VAT category cannot be mapped (e.g. reverse charge) are left with vatType "+
+				"NONE and reported as warnings"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("content", mcp.Description("The EHF XML document as text")),
+			mcp.WithString("content_base64", mcp.Description("The EHF XML document, base64-encoded (alternative to content)")),
+			mcp.WithString("account", mcp.Required(), mcp.Description("Expense account for the lines, e.g. '6800'")),
+			mcp.WithString("project_id", mcp.Description("Optional project ID for the draft")),
+			mcp.WithString("create_missing_supplier", mcp.Description("Set to 'true' to create the supplier as a contact when no contact has its organization number")),
+			mcp.WithString("filename", mcp.Description("File name of the attached XML (default: the document number with .xml)")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			account := mcp.ExtractString(args, "account")
+			data := []byte(mcp.ExtractString(args, "content"))
+			if encoded := mcp.ExtractString(args, "content_base64"); encoded != "" {
+				decoded, err := base64.StdEncoding.DecodeString(encoded)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid base64 content: %v", err)), nil
+				}
+				data = decoded
+			}
+			if len(data) == 0 {
+				return mcp.NewToolResultError("either content or content_base64 is required"), nil
+			}
+			doc, err := ehf.Parse(data)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			warnings := append([]string{}, doc.Warnings...)
+			if doc.Supplier.OrganizationNumber == "" {
+				reason := "the document has no supplier identifiers"
+				if len(doc.Warnings) > 0 {
+					reason = strings.Join(doc.Warnings, "; ")
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("no valid Norwegian organization number for supplier %s: %s. "+
+					"Create the purchase draft with create_purchase_draft and pick the supplier contact by hand", doc.Supplier.Name, reason)), nil
+			}
+			if doc.KID != "" {
+				if _, err := fiken.ValidateKID(doc.KID, ""); err != nil {
+					warnings = append(warnings, err.Error()+"; check it against the invoice before paying")
+				}
+			}
+			supplier, err := findSupplier(client, slug, doc.Supplier)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if supplier == nil {
+				if mcp.ExtractString(args, "create_missing_supplier") != "true" {
+					return mcp.NewToolResultError(fmt.Sprintf("no contact has organization number %q (%s); create the supplier first or set create_missing_supplier to 'true'",
+						doc.Supplier.OrganizationNumber, doc.Supplier.Name)), nil
+				}
+				supplier, err = createSupplier(client, slug, doc.Supplier)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				warnings = append(warnings, fmt.Sprintf("created supplier contact %d for %s", supplier.ContactID, supplier.Name))
+			}
+
+			draft := purchaseDraft{
+				InvoiceIssueDate: doc.IssueDate,
+				DueDate:          doc.DueDate,
+				InvoiceNumber:    doc.Number,
+				Kid:              doc.KID,
+				ContactID:        supplier.ContactID,
+				Currency:         doc.Currency,
+				Lines:            []purchaseDraftLine{},
+			}
+			if v := mcp.ExtractString(args, "project_id"); v != "" {
+				if draft.ProjectID, err = strconv.ParseInt(v, 10, 64); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid project_id %q", v)), nil
+				}
+			}
+			var vat fiken.Amount
+			for _, l := range doc.Lines {
+				line := purchaseDraftLine{Text: l.Description, Account: account, Net: l.Net}
+				vatType, ok := ehf.PurchaseVatType(l.VatCategory, l.VatRate)
+				if !ok {
+					vatType = "NONE"
+					warnings = append(warnings, fmt.Sprintf("line %q has VAT category %s at %s %%, which needs a manual VAT type; it was set to NONE",
+						l.Description, l.VatCategory, fiken.Amount(l.VatRate)))
+				}
+				line.VatType = vatType
+				lineVat := fiken.Amount(0)
+				if vatType != "NONE" {
+					lineVat = fiken.VatCode{Rate: l.VatRate}.VatOn(l.Net)
+				}
+				line.Gross = line.Net + lineVat
+				vat += lineVat
+				if doc.Type == ehf.TypeCreditNote {
+					line.Net, line.Gross = -line.Net, -line.Gross
+				}
+				draft.Lines = append(draft.Lines, line)
+			}
+			if vat != doc.Vat {
+				warnings = append(warnings, fmt.Sprintf("VAT computed per line (%s) differs from the document's VAT total (%s)", vat, doc.Vat))
+			}
+
+			payload, err := json.Marshal(draft)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, status, location, err := client.PostLocation("/companies/"+slug+"/purchases/drafts", payload)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			draftID := path.Base(location)
+			if location == "" {
+				var created struct {
+					DraftID int64 `json:"draftId"`
+				}
+				if json.Unmarshal(body, &created) == nil && created.DraftID != 0 {
+					draftID = strconv.FormatInt(created.DraftID, 10)
+				}
+			}
+
+			attached := false
+			if draftID != "" && draftID != "." {
+				filename := mcp.ExtractString(args, "filename")
+				if filename == "" {
+					filename = strings.NewReplacer("/", "-", "\\", "-").Replace(doc.Number) + ".xml"
+				}
+				body, status, err := client.PostMultipart("/companies/"+slug+"/purchases/drafts/"+draftID+"/attachments",
+					fiken.BuildQueryParams("filename", filename), filename, data)
+				switch {
+				case err != nil:
+					warnings = append(warnings, "attaching the XML failed: "+err.Error())
+				case status >= 400:
+					warnings = append(warnings, fmt.Sprintf("attaching the XML failed: API error %d: %s", status, string(body)))
+				default:
+					attached = true
+				}
+			} else {
+				draftID = ""
+				warnings = append(warnings, "the draft ID was not returned, so the XML was not attached")
+			}
+
+			return jsonResult(struct {
+				DraftID  string        `json:"draftId,omitempty"`
+				Attached bool          `json:"attached"`
+				Supplier fiken.Contact `json:"supplier"`
+				Draft    purchaseDraft `json:"draft"`
+				Document ehf.Document  `json:"document"`
+				Warnings []string      `json:"warnings"`
+			}{draftID, attached, *supplier, draft, doc, warnings})
+		},
+	)
+}
+
+// findSupplier returns the contact with the party's organization number, or
+// nil when there is none.
+func findSupplier(client *fiken.Client, slug string, party ehf.Party) (*fiken.Contact, error) {
+	if party.OrganizationNumber == "" {
+		return nil, fmt.Errorf("the document has no supplier organization number")
+	}
+	var contacts []fiken.Contact
+	if err := client.GetAll("/companies/"+slug+"/contacts", fiken.BuildQueryParams("organizationNumber", party.OrganizationNumber), &contacts); err != nil {
+		return nil, err
+	}
+	for _, c := range contacts {
+		if strings.ReplaceAll(c.OrganizationNumber, " ", "") == party.OrganizationNumber {
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+// createSupplier creates a supplier contact for the party.
+func createSupplier(client *fiken.Client, slug string, party ehf.Party) (*fiken.Contact, error) {
+	c := fiken.Contact{
+		Name:               party.Name,
+		OrganizationNumber: party.OrganizationNumber,
+		Supplier:           true,
+		Address:            party.Address,
+	}
+	payload, err := json.Marshal(struct {
+		Name               string         `json:"name"`
+		OrganizationNumber string         `json:"organizationNumber"`
+		Supplier           bool           `json:"supplier"`
+		Address            *fiken.Address `json:"address,omitempty"`
+	}{c.Name, c.OrganizationNumber, c.Supplier, c.Address})
+	if err != nil {
+		return nil, err
+	}
+	body, status, location, err := client.PostLocation("/companies/"+slug+"/contacts", payload)
+	if err != nil {
+		return nil, err
+	}
+	if status >= 400 {
+		return nil, fmt.Errorf("creating supplier: API error %d: %s", status, string(body))
+	}
+	if c.ContactID, err = strconv.ParseInt(path.Base(location), 10, 64); err != nil {
+		return nil, fmt.Errorf("creating supplier: no contact ID in the response")
+	}
+	return &c, nil
+}
@@ -72,17 +72,24 @@ func registerInvoiceTools(s *server.MCPServer, client *fiken.Client) {
 			mcp.WithDescription("Creates a new invoice"),
 			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
 			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with invoice details (issueDate, dueDate, customerId, lines, etc.)")),
+			mcp.WithString("kid_from", mcp.Description(kidFromDescription)),
 		),
 		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := req.GetArguments()
 			slug := mcp.ExtractString(args, "company_slug")
-			bodyStr := mcp.ExtractString(args, "body")
+			bodyStr, err := customerInvoiceKID(client, slug, mcp.ExtractString(args, "body"), mcp.ExtractString(args, "kid_from"))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := validateBodyKID(bodyStr); err != nil {
+				return validationErrorResult(err), nil
+			}
 			body, status, err := client.Post("/companies/"+slug+"/invoices", []byte(bodyStr))
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 			if status >= 400 {
-				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+				return counterErrorResult(client, slug, "invoices", "invoice", status, body), nil
 			}
 			return mcp.NewToolResultText(string(body)), nil
 		},
@@ -163,11 +170,20 @@ func registerInvoiceTools(s *server.MCPServer, client *fiken.Client) {
 			mcp.WithDescription("Creates a new invoice draft"),
 			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
 			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with invoice draft details")),
+			mcp.WithString("kid_from", mcp.Description(kidFromDescription+
+				". The draft gets its invoice number when it is turned into an invoice, so create no other invoice in between")),
 		),
 		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := req.GetArguments()
 			slug := mcp.ExtractString(args, "company_slug")
-			bodyStr := mcp.ExtractString(args, "body")
+			bodyStr, err := customerInvoiceKID(client, slug, mcp.ExtractString(args, "body"), mcp.ExtractString(args, "kid_from"))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			bodyStr, err = checkBodyLines(bodyStr, invoiceLines)
+			if err != nil {
+				return validationErrorResult(err), nil
+			}
 			body, status, err := client.Post("/companies/"+slug+"/invoices/drafts", []byte(bodyStr))
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -238,7 +254,7 @@ func registerInvoiceTools(s *server.MCPServer, client *fiken.Client) {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 			if status >= 400 {
-				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+				return counterErrorResult(client, slug, "invoices", "invoice", status, body), nil
 			}
 			return mcp.NewToolResultText(string(body)), nil
 		},
@@ -298,4 +314,46 @@ func registerInvoiceTools(s *server.MCPServer, client *fiken.Client) {
 			return mcp.NewToolResultText(string(body)), nil
 		},
 	)
+
+	s.AddTool(
+		mcp.NewTool("create_full_credit_note",
+			mcp.WithDescription("Creates a credit note that credits an entire invoice"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with credit note details (invoiceId, issueDate, creditNoteText)")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			bodyStr := mcp.ExtractString(args, "body")
+			body, status, err := client.Post("/companies/"+slug+"/creditNotes/full", []byte(bodyStr))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return counterErrorResult(client, slug, "creditNotes", "credit_note", status, body), nil
+			}
+			return mcp.NewToolResultText(string(body)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("create_partial_credit_note",
+			mcp.WithDescription("Creates a credit note for part of an invoice, or a standalone credit note for a customer"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with credit note details (issueDate, lines, invoiceId or customerId, creditNoteText, etc.)")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			bodyStr := mcp.ExtractString(args, "body")
+			body, status, err := client.Post("/companies/"+slug+"/creditNotes/partial", []byte(bodyStr))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return counterErrorResult(client, slug, "creditNotes", "credit_note", status, body), nil
+			}
+			return mcp.NewToolResultText(string(body)), nil
+		},
+	)
 }
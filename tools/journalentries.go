@@ -2,14 +2,19 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/simenandre/fiken-mcp/internal/fiken"
+	"github.com/simenandre/fiken-mcp/internal/journal"
 )
 
-func registerJournalEntryTools(s *server.MCPServer, client *fiken.Client) {
+func registerJournalEntryTools(s *server.MCPServer, client *fiken.Client, cfg Config) {
+	charts := newChartCache(client)
+
 	s.AddTool(
 		mcp.NewTool("get_journal_entries",
 			mcp.WithDescription("Returns all general journal entries for the specified company"),
@@ -78,7 +83,9 @@ func registerJournalEntryTools(s *server.MCPServer, client *fiken.Client) {
 
 	s.AddTool(
 		mcp.NewTool("create_general_journal_entry",
-			mcp.WithDescription("Creates a new general journal entry (fri postering)"),
+			mcp.WithDescription("Creates a new general journal entry (fri postering). The entry is validated first: lines must "+
+				"balance to the øre, accounts must exist in the chart of accounts, VAT codes must exist and suit the account, and "+
+				"the date must not be in a closed period"),
 			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
 			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with journal entry details (description, date, lines, etc.)")),
 		),
@@ -86,6 +93,13 @@ func registerJournalEntryTools(s *server.MCPServer, client *fiken.Client) {
 			args := req.GetArguments()
 			slug := mcp.ExtractString(args, "company_slug")
 			bodyStr := mcp.ExtractString(args, "body")
+			problems, err := validateJournalEntry(charts, slug, bodyStr, cfg.ClosedUntil)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(problems) > 0 {
+				return mcp.NewToolResultError("journal entry not posted:\n" + strings.Join(problems, "\n")), nil
+			}
 			body, status, err := client.Post("/companies/"+slug+"/generalJournalEntries", []byte(bodyStr))
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -96,4 +110,87 @@ func registerJournalEntryTools(s *server.MCPServer, client *fiken.Client) {
 			return mcp.NewToolResultText(string(body)), nil
 		},
 	)
+
+	s.AddTool(
+		mcp.NewTool("validate_journal_entry",
+			mcp.WithDescription("Checks a general journal entry body the way create_general_journal_entry does, without posting it"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with journal entry details")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			problems, err := validateJournalEntry(charts, mcp.ExtractString(args, "company_slug"), mcp.ExtractString(args, "body"), cfg.ClosedUntil)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return jsonResult(struct {
+				Valid    bool     `json:"valid"`
+				Problems []string `json:"problems"`
+			}{len(problems) == 0, problems})
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("cancel_journal_entry",
+			mcp.WithDescription("Cancels a journal entry by deleting its transaction. Fiken books a reversal and records the given reason"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("journal_entry_id", mcp.Required(), mcp.Description("The journal entry ID")),
+			mcp.WithString("description", mcp.Required(), mcp.Description("Reason for cancelling the journal entry; recorded with the reversal")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "journal_entry_id")
+			description := mcp.ExtractString(args, "description")
+			if description == "" {
+				return mcp.NewToolResultError("description is required when cancelling a journal entry"), nil
+			}
+			body, status, err := client.Get("/companies/"+slug+"/journalEntries/"+id, nil)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			var entry struct {
+				TransactionID json.Number `json:"transactionId"`
+			}
+			if err := json.Unmarshal(body, &entry); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("parsing journal entry: %v", err)), nil
+			}
+			if entry.TransactionID == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("journal entry %s has no transaction to cancel", id)), nil
+			}
+			params := fiken.BuildQueryParams("description", description)
+			body, status, err = client.Patch("/companies/"+slug+"/transactions/"+entry.TransactionID.String()+"/delete", nil, params)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			if len(body) > 0 {
+				return mcp.NewToolResultText(string(body)), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Journal entry %s cancelled (transaction %s deleted)", id, entry.TransactionID)), nil
+		},
+	)
+}
+
+// validateJournalEntry checks a general journal entry body against the
+// company's chart of accounts and the closed period.
+func validateJournalEntry(charts *chartCache, slug, body, closedUntil string) ([]string, error) {
+	r, err := journal.ParseRequest([]byte(body))
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := charts.accounts(slug)
+	if err != nil {
+		return nil, fmt.Errorf("fetching the chart of accounts: %w", err)
+	}
+	problems := []string{}
+	for _, p := range journal.Validate(r, journal.Rules{Accounts: accounts, ClosedUntil: closedUntil}) {
+		problems = append(problems, p.String())
+	}
+	return problems, nil
 }
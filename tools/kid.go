@@ -0,0 +1,216 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// kidCheck is the result of validating one KID.
+type kidCheck struct {
+	Kid       string `json:"kid"`
+	Valid     bool   `json:"valid"`
+	Algorithm string `json:"algorithm,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+func checkKID(kid, algorithm string) kidCheck {
+	alg, err := fiken.ValidateKID(kid, algorithm)
+	if err != nil {
+		return kidCheck{Kid: kid, Error: err.Error()}
+	}
+	return kidCheck{Kid: kid, Valid: true, Algorithm: alg}
+}
+
+// validateBodyKID checks the "kid" field of a JSON request body, if any, so
+// a KID with a wrong check digit is rejected before it reaches Fiken.
+func validateBodyKID(body string) error {
+	var fields struct {
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal([]byte(body), &fields); err != nil || fields.Kid == "" {
+		return nil
+	}
+	_, err := fiken.ValidateKID(fields.Kid, "")
+	return err
+}
+
+// kidFromCustomerAndInvoiceNumber is the kid_from value on the invoice tools
+// that fills in the KID generate_kid would give for the invoice's customer
+// number and invoice number.
+const kidFromCustomerAndInvoiceNumber = "customer_and_invoice_number"
+
+// kidFromDescription documents the kid_from argument of the invoice tools.
+const kidFromDescription = "Set to '" + kidFromCustomerAndInvoiceNumber + "' to fill in the body's kid as generate_kid does with its defaults " +
+	"(5-digit customer number, 6-digit invoice number, MOD10), using the customer's number and the next invoice number from the invoice counter"
+
+// customerInvoiceKID fills in the "kid" field of an invoice or invoice draft
+// body from the customer's number (looked up from customerId) and the next
+// number on the invoice counter. An empty kidFrom leaves the body unchanged.
+func customerInvoiceKID(client *fiken.Client, slug, body, kidFrom string) (string, error) {
+	if kidFrom == "" {
+		return body, nil
+	}
+	if kidFrom != kidFromCustomerAndInvoiceNumber {
+		return "", fmt.Errorf("unknown kid_from %q, expected %q", kidFrom, kidFromCustomerAndInvoiceNumber)
+	}
+	var fields struct {
+		CustomerID int64 `json:"customerId"`
+	}
+	if err := json.Unmarshal([]byte(body), &fields); err != nil {
+		return "", fmt.Errorf("invalid JSON body: %w", err)
+	}
+	if fields.CustomerID == 0 {
+		return "", fmt.Errorf("kid_from needs the body's customerId")
+	}
+	var contact fiken.Contact
+	if err := client.GetJSON("/companies/"+slug+"/contacts/"+strconv.FormatInt(fields.CustomerID, 10), nil, &contact); err != nil {
+		return "", err
+	}
+	var counter struct {
+		Value int64 `json:"value"`
+	}
+	if err := client.GetJSON("/companies/"+slug+"/invoices/counter", nil, &counter); err != nil {
+		var apiErr *fiken.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return "", fmt.Errorf("the invoice counter has not been initialized; call create_invoice_counter first")
+		}
+		return "", err
+	}
+	return setBodyKID(body, contact.CustomerNumber, counter.Value+1)
+}
+
+// setBodyKID sets the "kid" field of body to the KID for customerNumber and
+// invoiceNumber, keeping every other field as sent. A body that already has
+// a kid is rejected rather than overwritten.
+func setBodyKID(body string, customerNumber, invoiceNumber int64) (string, error) {
+	if customerNumber == 0 {
+		return "", fmt.Errorf("the customer has no customer number")
+	}
+	dec := json.NewDecoder(bytes.NewReader([]byte(body)))
+	dec.UseNumber()
+	var doc map[string]interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return "", fmt.Errorf("invalid JSON body: %w", err)
+	}
+	if kid, _ := doc["kid"].(string); kid != "" {
+		return "", fmt.Errorf("the body already has a kid; leave out either kid or kid_from")
+	}
+	kid, err := fiken.GenerateKID(customerNumber, invoiceNumber, 5, 6, fiken.KIDMod10)
+	if err != nil {
+		return "", err
+	}
+	doc["kid"] = kid
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func registerKIDTools(s *server.MCPServer, client *fiken.Client) {
+	s.AddTool(
+		mcp.NewTool("validate_kid",
+			mcp.WithDescription("Checks the length, characters and MOD10 or MOD11 check digit of a KID (Norwegian payment reference)"),
+			mcp.WithString("kid", mcp.Required(), mcp.Description("The KID to check")),
+			mcp.WithString("algorithm", mcp.Description("'MOD10' or 'MOD11'. Either is accepted when omitted")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			return jsonResult(checkKID(mcp.ExtractString(args, "kid"), mcp.ExtractString(args, "algorithm")))
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("generate_kid",
+			mcp.WithDescription("Generates a KID from a customer number and an invoice number, each zero-padded to a fixed width, "+
+				"followed by a MOD10 or MOD11 check digit"),
+			mcp.WithNumber("invoice_number", mcp.Required(), mcp.Description("The invoice number")),
+			mcp.WithNumber("customer_number", mcp.Description("The customer number; left out of the KID when omitted")),
+			mcp.WithNumber("customer_digits", mcp.Description("Width of the customer number part (default: 5 when a customer number is given)")),
+			mcp.WithNumber("invoice_digits", mcp.Description("Width of the invoice number part (default: 6)")),
+			mcp.WithString("algorithm", mcp.Description("'MOD10' (default) or 'MOD11'")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			invoiceNumber, ok := args["invoice_number"].(float64)
+			if !ok {
+				return mcp.NewToolResultError("invoice_number is required"), nil
+			}
+			var customerNumber float64
+			customerDigits, invoiceDigits := 0, 6
+			if v, ok := args["customer_number"].(float64); ok {
+				customerNumber = v
+				customerDigits = 5
+			}
+			if v, ok := args["customer_digits"].(float64); ok && v >= 0 {
+				customerDigits = int(v)
+			}
+			if v, ok := args["invoice_digits"].(float64); ok && v >= 0 {
+				invoiceDigits = int(v)
+			}
+			algorithm := mcp.ExtractString(args, "algorithm")
+			if algorithm == "" {
+				algorithm = fiken.KIDMod10
+			}
+			kid, err := fiken.GenerateKID(int64(customerNumber), int64(invoiceNumber), customerDigits, invoiceDigits, algorithm)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return jsonResult(checkKID(kid, algorithm))
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("check_purchase_kids",
+			mcp.WithDescription("Validates the KID on every unpaid purchase, so references with a wrong check digit are corrected "+
+				"before the payment is sent to the bank. Returns the purchases with an invalid KID"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			var purchases []fiken.Purchase
+			if err := client.GetAll("/companies/"+slug+"/purchases", nil, &purchases); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			type invalidKID struct {
+				PurchaseID int64  `json:"purchaseId"`
+				Identifier string `json:"identifier,omitempty"`
+				Supplier   string `json:"supplier,omitempty"`
+				DueDate    string `json:"dueDate,omitempty"`
+				kidCheck
+			}
+			checked := 0
+			invalid := []invalidKID{}
+			for _, p := range purchases {
+				if p.Paid || p.Deleted || p.Kid == "" {
+					continue
+				}
+				checked++
+				c := checkKID(p.Kid, "")
+				if c.Valid {
+					continue
+				}
+				item := invalidKID{PurchaseID: p.PurchaseID, Identifier: p.Identifier, DueDate: p.DueDate, kidCheck: c}
+				if p.Supplier != nil {
+					item.Supplier = p.Supplier.Name
+				}
+				invalid = append(invalid, item)
+			}
+			return jsonResult(struct {
+				Checked int          `json:"checked"`
+				Invalid []invalidKID `json:"invalid"`
+			}{checked, invalid})
+		},
+	)
+}
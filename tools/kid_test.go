@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func TestSetBodyKID(t *testing.T) {
+	out, err := setBodyKID(`{"customerId":7,"lines":[{"net":100.10}]}`, 42, 1001)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc struct {
+		Kid        string `json:"kid"`
+		CustomerID int64  `json:"customerId"`
+		Lines      []struct {
+			Net json.Number `json:"net"`
+		} `json:"lines"`
+	}
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("invalid body %s: %v", out, err)
+	}
+	want, _ := fiken.GenerateKID(42, 1001, 5, 6, fiken.KIDMod10)
+	if doc.Kid != want {
+		t.Errorf("kid = %q, want %q as generate_kid gives", doc.Kid, want)
+	}
+	if doc.CustomerID != 7 || len(doc.Lines) != 1 || doc.Lines[0].Net.String() != "100.10" {
+		t.Errorf("other fields changed: %s", out)
+	}
+	if err := validateBodyKID(out); err != nil {
+		t.Errorf("generated body fails KID validation: %v", err)
+	}
+
+	if _, err := setBodyKID(`{"customerId":7,"kid":"79927398713"}`, 42, 1001); err == nil {
+		t.Error("expected an error for a body that already has a kid")
+	}
+	if _, err := setBodyKID(`{"customerId":7}`, 0, 1001); err == nil {
+		t.Error("expected an error for a customer without a customer number")
+	}
+}
+
+func TestCustomerInvoiceKIDArguments(t *testing.T) {
+	body := `{"customerId":7}`
+	if out, err := customerInvoiceKID(nil, "acme", body, ""); err != nil || out != body {
+		t.Errorf("without kid_from: got %q, %v; want the body unchanged", out, err)
+	}
+	if _, err := customerInvoiceKID(nil, "acme", body, "invoice_number"); err == nil {
+		t.Error("expected an error for an unknown kid_from")
+	}
+	if _, err := customerInvoiceKID(nil, "acme", `{"lines":[]}`, kidFromCustomerAndInvoiceNumber); err == nil {
+		t.Error("expected an error for a body without customerId")
+	}
+}
@@ -0,0 +1,206 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// lineInput is a calculate_lines line. Quantity and discount may be JSON
+// numbers or numeric strings.
+type lineInput struct {
+	Description string       `json:"description"`
+	Quantity    json.Number  `json:"quantity"`
+	UnitPrice   fiken.Amount `json:"unitPrice"`
+	Discount    json.Number  `json:"discount"`
+	VatType     string       `json:"vatType"`
+}
+
+func registerLineTools(s *server.MCPServer, client *fiken.Client) {
+	s.AddTool(
+		mcp.NewTool("calculate_lines",
+			mcp.WithDescription("Calculates net, VAT and gross for order lines exactly as Fiken rounds them: net is quantity × unit "+
+				"price less the discount, VAT is the net at the vatType's rate (25, 15, 12 or 11.11 %; exempt, outside scope and "+
+				"reverse charge types carry none), each rounded to the øre, and totals are sums of the rounded lines. "+
+				"Use the results in invoice, sale and purchase bodies instead of computing them"),
+			mcp.WithString("lines", mcp.Required(), mcp.Description("JSON array of lines: "+
+				`[{"description": "...", "quantity": 2, "unitPrice": 199.90, "discount": 10, "vatType": "HIGH"}]. `+
+				"unitPrice is in NOK excluding VAT; discount is a percentage")),
+			mcp.WithString("kind", mcp.Description("'sale' (default) for sale vatTypes, or 'purchase' for purchase vatTypes")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			var raw []lineInput
+			if err := json.Unmarshal([]byte(mcp.ExtractString(args, "lines")), &raw); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid lines JSON: %v", err)), nil
+			}
+			purchase := false
+			switch mcp.ExtractString(args, "kind") {
+			case "", "sale":
+			case "purchase":
+				purchase = true
+			default:
+				return mcp.NewToolResultError("kind must be 'sale' or 'purchase'"), nil
+			}
+			lines := make([]fiken.LineInput, len(raw))
+			for i, l := range raw {
+				lines[i] = fiken.LineInput{
+					Description: l.Description,
+					Quantity:    l.Quantity.String(),
+					UnitPrice:   l.UnitPrice,
+					Discount:    l.Discount.String(),
+					VatType:     l.VatType,
+				}
+			}
+			res, err := fiken.CalculateLines(lines, purchase)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return jsonResult(res)
+		},
+	)
+}
+
+// Line layouts checked by checkBodyLines.
+const (
+	// invoiceLines have quantity, unitPrice, discount and vatType, with
+	// optional net, vat and gross.
+	invoiceLines = iota
+	// saleLines and purchaseLines have netPrice, vat and vatType.
+	saleLines
+	purchaseLines
+)
+
+// checkBodyLines checks the line amounts of a request body against the line
+// calculator. Invoice lines that state net, vat or gross must match the
+// calculated values. Sale and purchase lines must have the VAT of their
+// netPrice at the vatType's rate; a missing vat is filled in. Purchase lines
+// may differ by one øre, since suppliers round VAT on their own documents.
+// Lines with a VAT-only or unknown vatType are not checked. It returns the
+// body to send.
+func checkBodyLines(body string, layout int) (string, error) {
+	dec := json.NewDecoder(bytes.NewReader([]byte(body)))
+	dec.UseNumber()
+	var doc map[string]interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return body, nil
+	}
+	lines, _ := doc["lines"].([]interface{})
+	changed := false
+	for i, item := range lines {
+		line, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		vatType, _ := line["vatType"].(string)
+		if vatType == "" {
+			continue
+		}
+		// VAT-only (*_DIRECT) and unknown vatTypes are left to Fiken.
+		rate, _, err := fiken.LineVatRate(vatType, layout == purchaseLines)
+		if err != nil {
+			continue
+		}
+		path := fmt.Sprintf("lines[%d]", i)
+		switch layout {
+		case invoiceLines:
+			unitPrice, ok, err := bodyAmount(line, "unitPrice")
+			if err != nil {
+				return "", pathError(path+".unitPrice", err)
+			}
+			if !ok {
+				continue
+			}
+			res, err := fiken.CalculateLine(fiken.LineInput{
+				Quantity:  bodyNumber(line, "quantity"),
+				UnitPrice: unitPrice,
+				Discount:  bodyNumber(line, "discount"),
+				VatType:   vatType,
+			}, false)
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", path, err)
+			}
+			for _, f := range []struct {
+				key  string
+				want fiken.Amount
+			}{{"net", res.Net}, {"vat", res.Vat}, {"gross", res.Gross}} {
+				got, ok, err := bodyAmount(line, f.key)
+				if err != nil {
+					return "", pathError(path+"."+f.key, err)
+				}
+				if ok && got != f.want {
+					return "", fmt.Errorf("%s.%s is %s, but quantity × unitPrice less discount at %s gives net %s, vat %s, gross %s",
+						path, f.key, got, vatType, res.Net, res.Vat, res.Gross)
+				}
+			}
+		case saleLines, purchaseLines:
+			netPrice, ok, err := bodyAmount(line, "netPrice")
+			if err != nil {
+				return "", pathError(path+".netPrice", err)
+			}
+			if !ok {
+				continue
+			}
+			want := fiken.VatCode{Rate: rate}.VatOn(netPrice)
+			got, ok, err := bodyAmount(line, "vat")
+			if err != nil {
+				return "", pathError(path+".vat", err)
+			}
+			if !ok {
+				line["vat"] = json.Number(want.String())
+				changed = true
+				continue
+			}
+			diff := got - want
+			if diff < 0 {
+				diff = -diff
+			}
+			if diff > 0 && (layout == saleLines || diff > 1) {
+				return "", fmt.Errorf("%s.vat is %s, but %s %% VAT (%s) on netPrice %s is %s",
+					path, got, fiken.Amount(rate), vatType, netPrice, want)
+			}
+		}
+	}
+	if !changed {
+		return body, nil
+	}
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// bodyAmount reads a NOK amount from a decoded body line.
+func bodyAmount(line map[string]interface{}, key string) (fiken.Amount, bool, error) {
+	v, ok := line[key]
+	if !ok || v == nil {
+		return 0, false, nil
+	}
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false, fmt.Errorf("expected a number")
+	}
+	a, err := fiken.ParseAmount(n.String())
+	return a, err == nil, err
+}
+
+// bodyNumber reads a decimal number from a decoded body line as a string.
+func bodyNumber(line map[string]interface{}, key string) string {
+	switch v := line[key].(type) {
+	case json.Number:
+		return v.String()
+	case string:
+		return v
+	}
+	return ""
+}
+
+func pathError(path string, err error) error {
+	return fmt.Errorf("%s: %w", path, err)
+}
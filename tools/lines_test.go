@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCheckBodyLinesFillsVat(t *testing.T) {
+	out, err := checkBodyLines(`{"lines":[{"netPrice":100.10,"vatType":"HIGH"}]}`, saleLines)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc struct {
+		Lines []struct {
+			Vat json.Number `json:"vat"`
+		} `json:"lines"`
+	}
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("invalid body %s: %v", out, err)
+	}
+	if got := doc.Lines[0].Vat.String(); got != "25.03" {
+		t.Errorf("vat = %s, want 25.03", got)
+	}
+}
+
+func TestCheckBodyLinesRejectsWrongVat(t *testing.T) {
+	if _, err := checkBodyLines(`{"lines":[{"netPrice":100,"vat":20,"vatType":"HIGH"}]}`, saleLines); err == nil {
+		t.Error("expected an error for a sale line with the wrong vat")
+	}
+	if _, err := checkBodyLines(`{"lines":[{"netPrice":100,"vat":25.01,"vatType":"HIGH"}]}`, purchaseLines); err != nil {
+		t.Errorf("purchase line one øre off: unexpected error: %v", err)
+	}
+	if _, err := checkBodyLines(`{"lines":[{"quantity":2,"unitPrice":10,"net":20,"vat":4,"vatType":"HIGH"}]}`, invoiceLines); err == nil {
+		t.Error("expected an error for an invoice line with the wrong vat")
+	}
+}
+
+func TestCheckBodyLinesSkipsDirectAndUnknownVatTypes(t *testing.T) {
+	for _, body := range []string{
+		// Customs import VAT: the whole line is VAT.
+		`{"lines":[{"netPrice":0,"vat":250,"vatType":"HIGH_DIRECT","account":"2711"}]}`,
+		`{"lines":[{"netPrice":1000,"vatType":"MEDIUM_DIRECT"}]}`,
+		`{"lines":[{"netPrice":1000,"vat":1,"vatType":"SOMETHING_NEW"}]}`,
+	} {
+		out, err := checkBodyLines(body, purchaseLines)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", body, err)
+			continue
+		}
+		if out != body {
+			t.Errorf("%s: body changed to %s", body, out)
+		}
+	}
+}
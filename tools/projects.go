@@ -3,10 +3,12 @@ package tools
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/simenandre/fiken-mcp/internal/fiken"
+	"github.com/simenandre/fiken-mcp/internal/report"
 )
 
 func registerProjectTools(s *server.MCPServer, client *fiken.Client) {
@@ -101,4 +103,67 @@ func registerProjectTools(s *server.MCPServer, client *fiken.Client) {
 			return mcp.NewToolResultText(string(body)), nil
 		},
 	)
+
+	s.AddTool(
+		mcp.NewTool("delete_project",
+			mcp.WithDescription("Deletes a project"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("project_id", mcp.Required(), mcp.Description("The project ID")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "project_id")
+			body, status, err := client.Delete("/companies/" + slug + "/projects/" + id)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Project %s deleted successfully", id)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("get_project_summary",
+			mcp.WithDescription("Computes revenue, cost and margin for a project from its sales, purchases and journal entry lines over a date range. "+
+				"Amounts are net of VAT and summed exactly in øre"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("project_id", mcp.Required(), mcp.Description("The project ID")),
+			mcp.WithString("from_date", mcp.Description("Start of the period (YYYY-MM-DD, inclusive)")),
+			mcp.WithString("to_date", mcp.Description("End of the period (YYYY-MM-DD, inclusive)")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			projectID, err := strconv.ParseInt(mcp.ExtractString(args, "project_id"), 10, 64)
+			if err != nil {
+				return mcp.NewToolResultError("project_id must be a numeric project ID"), nil
+			}
+			period := report.Period{
+				From: mcp.ExtractString(args, "from_date"),
+				To:   mcp.ExtractString(args, "to_date"),
+			}
+			dateParams := fiken.BuildQueryParams(
+				"dateGe", period.From,
+				"dateLe", period.To,
+			)
+
+			var sales []fiken.Sale
+			if err := client.GetAll("/companies/"+slug+"/sales", dateParams, &sales); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var purchases []fiken.Purchase
+			if err := client.GetAll("/companies/"+slug+"/purchases", dateParams, &purchases); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var entries []fiken.JournalEntry
+			if err := client.GetAll("/companies/"+slug+"/journalEntries", dateParams, &entries); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return jsonResult(report.SummarizeProject(projectID, period, sales, purchases, entries))
+		},
+	)
 }
@@ -6,10 +6,11 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/simenandre/fiken-mcp/internal/duplicates"
 	"github.com/simenandre/fiken-mcp/internal/fiken"
 )
 
-func registerPurchaseTools(s *server.MCPServer, client *fiken.Client) {
+func registerPurchaseTools(s *server.MCPServer, client *fiken.Client, cfg Config) {
 	s.AddTool(
 		mcp.NewTool("get_purchases",
 			mcp.WithDescription("Returns all purchases for a company"),
@@ -64,12 +65,27 @@ func registerPurchaseTools(s *server.MCPServer, client *fiken.Client) {
 		mcp.NewTool("create_purchase",
 			mcp.WithDescription("Creates a new purchase"),
 			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
-			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with purchase details (date, kind, lines, paymentAccount, etc.)")),
+			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with purchase details (date, kind, lines, paymentAccount, etc.). Line netPrice, vat and grossPrice are in NOK")),
+			mcp.WithString("allow_duplicate", mcp.Description("Set to 'true' to book the purchase even if it matches an existing one")),
 		),
 		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := req.GetArguments()
 			slug := mcp.ExtractString(args, "company_slug")
 			bodyStr := mcp.ExtractString(args, "body")
+			if err := validateBodyKID(bodyStr); err != nil {
+				return validationErrorResult(err), nil
+			}
+			bodyStr, err := checkBodyLines(bodyStr, purchaseLines)
+			if err != nil {
+				return validationErrorResult(err), nil
+			}
+			var matches []duplicates.PurchaseMatch
+			if p, ok := purchaseBody(bodyStr); ok && cfg.DuplicatePurchases != DuplicatePurchasesOff {
+				var blocked *mcp.CallToolResult
+				if matches, blocked = checkDuplicatePurchase(client, slug, p, cfg.DuplicatePurchases, mcp.ExtractString(args, "allow_duplicate") == "true"); blocked != nil {
+					return blocked, nil
+				}
+			}
 			body, status, err := client.Post("/companies/"+slug+"/purchases", []byte(bodyStr))
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -77,7 +93,37 @@ func registerPurchaseTools(s *server.MCPServer, client *fiken.Client) {
 			if status >= 400 {
 				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
 			}
-			return mcp.NewToolResultText(string(body)), nil
+			return duplicateWarningResult(body, matches)
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("delete_purchase",
+			mcp.WithDescription("Deletes a booked purchase. Fiken keeps the purchase in the audit trail and books a reversal, recording the given reason"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("purchase_id", mcp.Required(), mcp.Description("The purchase ID")),
+			mcp.WithString("description", mcp.Required(), mcp.Description("Reason for deleting the purchase; recorded with the reversal")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "purchase_id")
+			description := mcp.ExtractString(args, "description")
+			if description == "" {
+				return mcp.NewToolResultError("description is required when deleting a purchase"), nil
+			}
+			params := fiken.BuildQueryParams("description", description)
+			body, status, err := client.Patch("/companies/"+slug+"/purchases/"+id+"/delete", nil, params)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			if len(body) > 0 {
+				return mcp.NewToolResultText(string(body)), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Purchase %s deleted successfully", id)), nil
 		},
 	)
 
@@ -132,12 +178,15 @@ func registerPurchaseTools(s *server.MCPServer, client *fiken.Client) {
 		mcp.NewTool("create_purchase_draft",
 			mcp.WithDescription("Creates a new purchase draft"),
 			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
-			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with purchase draft details")),
+			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with purchase draft details. Line netPrice, vat and grossPrice are in NOK")),
 		),
 		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := req.GetArguments()
 			slug := mcp.ExtractString(args, "company_slug")
 			bodyStr := mcp.ExtractString(args, "body")
+			if err := validateBodyKID(bodyStr); err != nil {
+				return validationErrorResult(err), nil
+			}
 			body, status, err := client.Post("/companies/"+slug+"/purchases/drafts", []byte(bodyStr))
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -175,11 +224,25 @@ func registerPurchaseTools(s *server.MCPServer, client *fiken.Client) {
 			mcp.WithDescription("Creates a purchase from an existing draft"),
 			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
 			mcp.WithString("draft_id", mcp.Required(), mcp.Description("The draft ID")),
+			mcp.WithString("allow_duplicate", mcp.Description("Set to 'true' to book the purchase even if it matches an existing one")),
 		),
 		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := req.GetArguments()
 			slug := mcp.ExtractString(args, "company_slug")
 			id := mcp.ExtractString(args, "draft_id")
+			var matches []duplicates.PurchaseMatch
+			if cfg.DuplicatePurchases != DuplicatePurchasesOff {
+				p, ok, err := purchaseDraftPurchase(client, slug, id)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					var blocked *mcp.CallToolResult
+					if matches, blocked = checkDuplicatePurchase(client, slug, p, cfg.DuplicatePurchases, mcp.ExtractString(args, "allow_duplicate") == "true"); blocked != nil {
+						return blocked, nil
+					}
+				}
+			}
 			body, status, err := client.Post("/companies/"+slug+"/purchases/drafts/"+id+"/createPurchase", nil)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -187,7 +250,7 @@ func registerPurchaseTools(s *server.MCPServer, client *fiken.Client) {
 			if status >= 400 {
 				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
 			}
-			return mcp.NewToolResultText(string(body)), nil
+			return duplicateWarningResult(body, matches)
 		},
 	)
 }
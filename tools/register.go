@@ -2,24 +2,36 @@ package tools
 
 import (
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/simenandre/fiken-mcp/internal/brreg"
 	"github.com/simenandre/fiken-mcp/internal/fiken"
 )
 
 // RegisterAll registers all Fiken tools with the MCP server.
-func RegisterAll(s *server.MCPServer, client *fiken.Client) {
+func RegisterAll(s *server.MCPServer, client *fiken.Client, cfg Config) {
 	registerUserTools(s, client)
 	registerCompanyTools(s, client)
 	registerAccountTools(s, client)
 	registerBankAccountTools(s, client)
+	registerBankStatementTools(s, client)
 	registerContactTools(s, client)
-	registerJournalEntryTools(s, client)
+	registerBrregTools(s, client, brreg.NewClient(cfg.BrregBaseURL))
+	registerDuplicateTools(s, client)
+	registerJournalEntryTools(s, client, cfg)
 	registerTransactionTools(s, client)
 	registerProductTools(s, client)
 	registerInvoiceTools(s, client)
-	registerPurchaseTools(s, client)
+	registerCounterTools(s, client)
+	registerKIDTools(s, client)
+	registerLineTools(s, client)
+	registerPurchaseTools(s, client, cfg)
+	registerEhfTools(s, client)
 	registerSalesTools(s, client)
 	registerProjectTools(s, client)
+	registerTimeTrackingTools(s, client)
 	registerOfferTools(s, client)
 	registerOrderConfirmationTools(s, client)
 	registerInboxTools(s, client)
+	registerReportTools(s, client)
+	registerAgingTools(s, client)
+	registerSaftTools(s, client)
 }
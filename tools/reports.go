@@ -0,0 +1,372 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+	"github.com/simenandre/fiken-mcp/internal/report"
+)
+
+const dateLayout = "2006-01-02"
+
+func registerReportTools(s *server.MCPServer, client *fiken.Client) {
+	s.AddTool(
+		mcp.NewTool("get_profit_and_loss",
+			mcp.WithDescription("Computes a profit and loss statement (resultatregnskap) for a period from account balances, "+
+				"grouped by NS 4102 account classes 3–8 with exact subtotals. The period must lie within one fiscal year"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("from_date", mcp.Required(), mcp.Description("Start of the period (YYYY-MM-DD, inclusive)")),
+			mcp.WithString("to_date", mcp.Required(), mcp.Description("End of the period (YYYY-MM-DD, inclusive)")),
+			mcp.WithString("compare_from_date", mcp.Description("Start of an optional comparison period (YYYY-MM-DD)")),
+			mcp.WithString("compare_to_date", mcp.Description("End of an optional comparison period (YYYY-MM-DD)")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			period := report.Period{
+				From: mcp.ExtractString(args, "from_date"),
+				To:   mcp.ExtractString(args, "to_date"),
+			}
+			movements, err := resultMovements(client, slug, period)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pl := report.BuildProfitAndLoss(period, movements)
+
+			compare := report.Period{
+				From: mcp.ExtractString(args, "compare_from_date"),
+				To:   mcp.ExtractString(args, "compare_to_date"),
+			}
+			if compare.From != "" || compare.To != "" {
+				compareMovements, err := resultMovements(client, slug, compare)
+				if err != nil {
+					return mcp.NewToolResultError("comparison period: " + err.Error()), nil
+				}
+				comparison := report.BuildProfitAndLoss(compare, compareMovements)
+				pl.Comparison = &comparison
+			}
+			return jsonResult(pl)
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("get_balance_sheet",
+			mcp.WithDescription("Computes a balance sheet (balanse) on a date from account balances 1000–2999, with exact totals. "+
+				"Includes the year's unallocated result and reports explicitly whether the sheet balances"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("date", mcp.Required(), mcp.Description("Balance sheet date (YYYY-MM-DD)")),
+			mcp.WithString("include_prior_year", mcp.Description("'true' to add a comparison column for the same date one year earlier")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			date, err := time.Parse(dateLayout, mcp.ExtractString(args, "date"))
+			if err != nil {
+				return mcp.NewToolResultError("date must be in YYYY-MM-DD format"), nil
+			}
+			bs, err := balanceSheet(client, slug, date)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if mcp.ExtractString(args, "include_prior_year") == "true" {
+				prior, err := balanceSheet(client, slug, date.AddDate(-1, 0, 0))
+				if err != nil {
+					return mcp.NewToolResultError("prior year: " + err.Error()), nil
+				}
+				bs.Comparison = &prior
+			}
+			return jsonResult(bs)
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("prepare_vat_return",
+			mcp.WithDescription("Prepares the VAT return (MVA-melding) for a two-month termin: basis and VAT per MVA code from sales, "+
+				"purchases and journal entries, the amount payable, and a reconciliation against the VAT accounts (2700–2739)"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("termin", mcp.Required(), mcp.Description("The termin as YEAR-TERM, e.g. '2026-5' for September–October 2026")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			termin := mcp.ExtractString(args, "termin")
+			period, err := report.ParseVatTermin(termin)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dateParams := fiken.BuildQueryParams(
+				"dateGe", period.From,
+				"dateLe", period.To,
+			)
+
+			var sales []fiken.Sale
+			if err := client.GetAll("/companies/"+slug+"/sales", dateParams, &sales); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var purchases []fiken.Purchase
+			if err := client.GetAll("/companies/"+slug+"/purchases", dateParams, &purchases); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var entries []fiken.JournalEntry
+			if err := client.GetAll("/companies/"+slug+"/journalEntries", dateParams, &entries); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			vatMovements, err := balanceMovements(client, slug, period, "2700", "2739")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return jsonResult(report.BuildVatReturn(termin, period, sales, purchases, entries, vatMovements))
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("get_general_ledger",
+			mcp.WithDescription("Returns the general ledger (hovedbok) for an account range and period: per account the opening balance, "+
+				"every journal entry posting with a running balance, and the closing balance, verified against the account balances "+
+				"reported by Fiken at both ends. The opening balance is computed from the fiscal year's opening balance and the "+
+				"entries since, and compared with Fiken's balance the day before the period"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("from_date", mcp.Required(), mcp.Description("Start of the period (YYYY-MM-DD, inclusive)")),
+			mcp.WithString("to_date", mcp.Required(), mcp.Description("End of the period (YYYY-MM-DD, inclusive)")),
+			mcp.WithString("from_account", mcp.Description("First account number in the range (default: 1000)")),
+			mcp.WithString("to_account", mcp.Description("Last account number in the range (default: 8999)")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			period := report.Period{
+				From: mcp.ExtractString(args, "from_date"),
+				To:   mcp.ExtractString(args, "to_date"),
+			}
+			fromAccount, toAccount, err := parseAccountRange(mcp.ExtractString(args, "from_account"), mcp.ExtractString(args, "to_account"))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opening, closing, err := periodBalances(client, slug, period, fromAccount, toAccount)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			yearStart := period.From[:4] + "-01-01"
+			yearOpening := opening
+			if period.From != yearStart {
+				if yearOpening, err = openingBalances(client, slug, yearStart, fromAccount, toAccount); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+			var entries []fiken.JournalEntry
+			dateParams := fiken.BuildQueryParams(
+				"dateGe", yearStart,
+				"dateLe", period.To,
+			)
+			if err := client.GetAll("/companies/"+slug+"/journalEntries", dateParams, &entries); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return jsonResult(report.BuildGeneralLedger(period, fromAccount, toAccount, yearOpening, opening, closing, entries))
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("get_trial_balance",
+			mcp.WithDescription("Returns a trial balance (saldobalanse) for a period: opening balance, movement and closing balance per account, "+
+				"with closing balances in debit and credit columns and a check that total debits equal total credits. "+
+				"Optionally compares the movements with another period, with absolute and percentage deltas"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("from_date", mcp.Required(), mcp.Description("Start of the period (YYYY-MM-DD, inclusive)")),
+			mcp.WithString("to_date", mcp.Required(), mcp.Description("End of the period (YYYY-MM-DD, inclusive)")),
+			mcp.WithString("compare_from_date", mcp.Description("Start of an optional comparison period (YYYY-MM-DD)")),
+			mcp.WithString("compare_to_date", mcp.Description("End of an optional comparison period (YYYY-MM-DD)")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			period := report.Period{
+				From: mcp.ExtractString(args, "from_date"),
+				To:   mcp.ExtractString(args, "to_date"),
+			}
+			opening, closing, err := periodBalances(client, slug, period, 1000, 8999)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tb := report.BuildTrialBalance(period, opening, closing)
+
+			compare := report.Period{
+				From: mcp.ExtractString(args, "compare_from_date"),
+				To:   mcp.ExtractString(args, "compare_to_date"),
+			}
+			if compare.From != "" || compare.To != "" {
+				compareOpening, compareClosing, err := periodBalances(client, slug, compare, 1000, 8999)
+				if err != nil {
+					return mcp.NewToolResultError("comparison period: " + err.Error()), nil
+				}
+				tb.CompareMovements(compare, report.Movements(compareOpening, compareClosing))
+			}
+			return jsonResult(tb)
+		},
+	)
+}
+
+// periodBalances returns the balances of the accounts in the range the day
+// before period starts and on its last day. Result accounts start each fiscal
+// year at zero, so their opening balances are dropped when the period starts
+// on 1 January, and a period including them may not span two years.
+func periodBalances(client *fiken.Client, slug string, period report.Period, fromAccount, toAccount int) ([]fiken.AccountBalance, []fiken.AccountBalance, error) {
+	from, to, err := parsePeriod(period)
+	if err != nil {
+		return nil, nil, err
+	}
+	if toAccount >= 3000 && from.Year() != to.Year() {
+		return nil, nil, fmt.Errorf("the period %s to %s spans more than one fiscal year, "+
+			"so result account balances cannot be carried over; split it per year", period.From, period.To)
+	}
+	accountFrom, accountTo := strconv.Itoa(fromAccount), strconv.Itoa(toAccount)
+	var opening []fiken.AccountBalance
+	if from.YearDay() == 1 {
+		opening, err = openingBalances(client, slug, period.From, fromAccount, toAccount)
+	} else {
+		opening, err = fetchAccountBalances(client, slug, from.AddDate(0, 0, -1).Format(dateLayout), accountFrom, accountTo)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	closing, err := fetchAccountBalances(client, slug, period.To, accountFrom, accountTo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return opening, closing, nil
+}
+
+// openingBalances returns the balances on the day before yearStart, the
+// first day of a fiscal year, without result accounts, which start the year
+// at zero.
+func openingBalances(client *fiken.Client, slug, yearStart string, fromAccount, toAccount int) ([]fiken.AccountBalance, error) {
+	start, err := time.Parse(dateLayout, yearStart)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := fetchAccountBalances(client, slug, start.AddDate(0, 0, -1).Format(dateLayout), strconv.Itoa(fromAccount), strconv.Itoa(toAccount))
+	if err != nil {
+		return nil, err
+	}
+	kept := balances[:0]
+	for _, b := range balances {
+		if fiken.AccountNumber(b.Code) < 3000 {
+			kept = append(kept, b)
+		}
+	}
+	return kept, nil
+}
+
+// parseAccountRange parses an optional account number range, defaulting to
+// all balance and result accounts (1000–8999).
+func parseAccountRange(fromStr, toStr string) (int, int, error) {
+	from, to := 1000, 8999
+	var err error
+	if fromStr != "" {
+		if from, err = strconv.Atoi(fromStr); err != nil {
+			return 0, 0, fmt.Errorf("invalid from_account %q, expected an account number", fromStr)
+		}
+	}
+	if toStr != "" {
+		if to, err = strconv.Atoi(toStr); err != nil {
+			return 0, 0, fmt.Errorf("invalid to_account %q, expected an account number", toStr)
+		}
+	}
+	if to < from {
+		return 0, 0, fmt.Errorf("to_account %d is before from_account %d", to, from)
+	}
+	return from, to, nil
+}
+
+// balanceMovements returns what was posted to balance sheet accounts in the
+// range during period: the closing balance at the end minus the closing
+// balance the day before the start.
+func balanceMovements(client *fiken.Client, slug string, period report.Period, fromAccount, toAccount string) ([]fiken.AccountBalance, error) {
+	from, _, err := parsePeriod(period)
+	if err != nil {
+		return nil, err
+	}
+	opening, err := fetchAccountBalances(client, slug, from.AddDate(0, 0, -1).Format(dateLayout), fromAccount, toAccount)
+	if err != nil {
+		return nil, err
+	}
+	closing, err := fetchAccountBalances(client, slug, period.To, fromAccount, toAccount)
+	if err != nil {
+		return nil, err
+	}
+	return report.Movements(opening, closing), nil
+}
+
+// balanceSheet fetches all balances on date and builds the balance sheet.
+// Result accounts are included so the year's unallocated result is known.
+func balanceSheet(client *fiken.Client, slug string, date time.Time) (report.BalanceSheet, error) {
+	d := date.Format(dateLayout)
+	balances, err := fetchAccountBalances(client, slug, d, "1000", "8999")
+	if err != nil {
+		return report.BalanceSheet{}, err
+	}
+	return report.BuildBalanceSheet(d, balances), nil
+}
+
+// fetchAccountBalances returns the closing balance on date of every account
+// in the given range, across all pages.
+func fetchAccountBalances(client *fiken.Client, slug, date, fromAccount, toAccount string) ([]fiken.AccountBalance, error) {
+	params := fiken.BuildQueryParams(
+		"date", date,
+		"fromAccount", fromAccount,
+		"toAccount", toAccount,
+	)
+	var balances []fiken.AccountBalance
+	if err := client.GetAll("/companies/"+slug+"/accountBalances", params, &balances); err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+// resultMovements returns what was posted to result accounts (3000–8999)
+// during period. Fiken reports result account balances year-to-date, so the
+// movement is the balance at the end of the period minus the balance the day
+// before it starts, unless the period starts on the first day of the year.
+func resultMovements(client *fiken.Client, slug string, period report.Period) ([]fiken.AccountBalance, error) {
+	from, to, err := parsePeriod(period)
+	if err != nil {
+		return nil, err
+	}
+	if from.Year() != to.Year() {
+		return nil, fmt.Errorf("the period %s to %s spans more than one fiscal year; split it per year", period.From, period.To)
+	}
+	closing, err := fetchAccountBalances(client, slug, period.To, "3000", "8999")
+	if err != nil {
+		return nil, err
+	}
+	var opening []fiken.AccountBalance
+	if from.YearDay() > 1 {
+		opening, err = fetchAccountBalances(client, slug, from.AddDate(0, 0, -1).Format(dateLayout), "3000", "8999")
+		if err != nil {
+			return nil, err
+		}
+	}
+	return report.Movements(opening, closing), nil
+}
+
+// parsePeriod validates and parses both bounds of a period.
+func parsePeriod(period report.Period) (time.Time, time.Time, error) {
+	from, err := time.Parse(dateLayout, period.From)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid from date %q, expected YYYY-MM-DD", period.From)
+	}
+	to, err := time.Parse(dateLayout, period.To)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid to date %q, expected YYYY-MM-DD", period.To)
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("the period ends (%s) before it starts (%s)", period.To, period.From)
+	}
+	return from, to, nil
+}
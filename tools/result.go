@@ -0,0 +1,16 @@
+package tools
+
+import (
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// jsonResult returns a locally computed value to the model as JSON text.
+func jsonResult(v interface{}) (*mcp.CallToolResult, error) {
+	out, err := json.Marshal(v)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(out)), nil
+}
@@ -0,0 +1,44 @@
+package tools
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+	"github.com/simenandre/fiken-mcp/internal/report"
+	"github.com/simenandre/fiken-mcp/internal/saft"
+)
+
+func registerSaftTools(s *server.MCPServer, client *fiken.Client) {
+	s.AddTool(
+		mcp.NewTool("export_saft",
+			mcp.WithDescription("Exports a SAF-T Financial 1.30 XML file for a period, assembled from the chart of accounts, account balances, "+
+				"contacts and journal entries. The period must lie within one fiscal year. Returns the XML document"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("from_date", mcp.Required(), mcp.Description("Start of the period (YYYY-MM-DD, inclusive)")),
+			mcp.WithString("to_date", mcp.Required(), mcp.Description("End of the period (YYYY-MM-DD, inclusive)")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			period := report.Period{
+				From: mcp.ExtractString(args, "from_date"),
+				To:   mcp.ExtractString(args, "to_date"),
+			}
+			in, err := saft.Fetch(client, mcp.ExtractString(args, "company_slug"), period)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			f, err := saft.Build(in)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var sb strings.Builder
+			if err := f.Encode(&sb); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return mcp.NewToolResultText(sb.String()), nil
+		},
+	)
+}
@@ -68,12 +68,19 @@ func registerSalesTools(s *server.MCPServer, client *fiken.Client) {
 		mcp.NewTool("create_sale",
 			mcp.WithDescription("Creates a new sale"),
 			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
-			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with sale details (date, kind, lines, paymentAccount, etc.)")),
+			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with sale details (date, kind, lines, paymentAccount, etc.). Line netPrice, vat and grossPrice are in NOK")),
 		),
 		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := req.GetArguments()
 			slug := mcp.ExtractString(args, "company_slug")
 			bodyStr := mcp.ExtractString(args, "body")
+			if err := validateBodyKID(bodyStr); err != nil {
+				return validationErrorResult(err), nil
+			}
+			bodyStr, err := checkBodyLines(bodyStr, saleLines)
+			if err != nil {
+				return validationErrorResult(err), nil
+			}
 			body, status, err := client.Post("/companies/"+slug+"/sales", []byte(bodyStr))
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -85,6 +92,36 @@ func registerSalesTools(s *server.MCPServer, client *fiken.Client) {
 		},
 	)
 
+	s.AddTool(
+		mcp.NewTool("delete_sale",
+			mcp.WithDescription("Deletes a booked sale. Fiken keeps the sale in the audit trail and books a reversal, recording the given reason"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("sale_id", mcp.Required(), mcp.Description("The sale ID")),
+			mcp.WithString("description", mcp.Required(), mcp.Description("Reason for deleting the sale; recorded with the reversal")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "sale_id")
+			description := mcp.ExtractString(args, "description")
+			if description == "" {
+				return mcp.NewToolResultError("description is required when deleting a sale"), nil
+			}
+			params := fiken.BuildQueryParams("description", description)
+			body, status, err := client.Patch("/companies/"+slug+"/sales/"+id+"/delete", nil, params)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			if len(body) > 0 {
+				return mcp.NewToolResultText(string(body)), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Sale %s deleted successfully", id)), nil
+		},
+	)
+
 	// Sale Drafts
 	s.AddTool(
 		mcp.NewTool("get_sale_drafts",
@@ -136,7 +173,7 @@ func registerSalesTools(s *server.MCPServer, client *fiken.Client) {
 		mcp.NewTool("create_sale_draft",
 			mcp.WithDescription("Creates a new sale draft"),
 			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
-			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with sale draft details")),
+			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with sale draft details. Line netPrice, vat and grossPrice are in NOK")),
 		),
 		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := req.GetArguments()
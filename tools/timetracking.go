@@ -0,0 +1,468 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func registerTimeTrackingTools(s *server.MCPServer, client *fiken.Client) {
+	// Activities
+	s.AddTool(
+		mcp.NewTool("get_activities",
+			mcp.WithDescription("Returns all time-tracking activities for a company (requires time-tracking module)"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithNumber("page", mcp.Description("Page number (0-based)")),
+			mcp.WithNumber("page_size", mcp.Description("Number of results per page (max 100)")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			params := fiken.BuildQueryParams(
+				"page", args["page"],
+				"pageSize", args["page_size"],
+			)
+			body, status, err := client.Get("/companies/"+slug+"/activities", params)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(string(body)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("get_activity",
+			mcp.WithDescription("Returns a specific time-tracking activity"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("activity_id", mcp.Required(), mcp.Description("The activity ID")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "activity_id")
+			body, status, err := client.Get("/companies/"+slug+"/activities/"+id, nil)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(string(body)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("create_activity",
+			mcp.WithDescription("Creates a new time-tracking activity"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with activity details (name, description, etc.)")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			bodyStr := mcp.ExtractString(args, "body")
+			body, status, err := client.Post("/companies/"+slug+"/activities", []byte(bodyStr))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(string(body)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("update_activity",
+			mcp.WithDescription("Updates an existing time-tracking activity"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("activity_id", mcp.Required(), mcp.Description("The activity ID")),
+			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with activity fields to update")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "activity_id")
+			bodyStr := mcp.ExtractString(args, "body")
+			body, status, err := client.Put("/companies/"+slug+"/activities/"+id, []byte(bodyStr))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(string(body)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("delete_activity",
+			mcp.WithDescription("Deletes a time-tracking activity"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("activity_id", mcp.Required(), mcp.Description("The activity ID")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "activity_id")
+			body, status, err := client.Delete("/companies/" + slug + "/activities/" + id)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Activity %s deleted successfully", id)), nil
+		},
+	)
+
+	// Time Entries
+	s.AddTool(
+		mcp.NewTool("get_time_entries",
+			mcp.WithDescription("Returns all time entries for a company (requires time-tracking module)"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithNumber("page", mcp.Description("Page number (0-based)")),
+			mcp.WithNumber("page_size", mcp.Description("Number of results per page (max 100)")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			params := fiken.BuildQueryParams(
+				"page", args["page"],
+				"pageSize", args["page_size"],
+			)
+			body, status, err := client.Get("/companies/"+slug+"/timeEntries", params)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(string(body)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("get_time_entry",
+			mcp.WithDescription("Returns a specific time entry"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("time_entry_id", mcp.Required(), mcp.Description("The time entry ID")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "time_entry_id")
+			body, status, err := client.Get("/companies/"+slug+"/timeEntries/"+id, nil)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(string(body)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("create_time_entry",
+			mcp.WithDescription("Registers a new time entry"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with time entry details (timeUserId, activityId, projectId, date, minutes, description, etc.)")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			bodyStr := mcp.ExtractString(args, "body")
+			body, status, err := client.Post("/companies/"+slug+"/timeEntries", []byte(bodyStr))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(string(body)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("update_time_entry",
+			mcp.WithDescription("Updates an existing time entry"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("time_entry_id", mcp.Required(), mcp.Description("The time entry ID")),
+			mcp.WithString("body", mcp.Required(), mcp.Description("JSON body with time entry fields to update")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "time_entry_id")
+			bodyStr := mcp.ExtractString(args, "body")
+			body, status, err := client.Put("/companies/"+slug+"/timeEntries/"+id, []byte(bodyStr))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(string(body)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("delete_time_entry",
+			mcp.WithDescription("Deletes a time entry"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("time_entry_id", mcp.Required(), mcp.Description("The time entry ID")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "time_entry_id")
+			body, status, err := client.Delete("/companies/" + slug + "/timeEntries/" + id)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Time entry %s deleted successfully", id)), nil
+		},
+	)
+
+	// Time Users
+	s.AddTool(
+		mcp.NewTool("get_time_users",
+			mcp.WithDescription("Returns all users that can register time for a company"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithNumber("page", mcp.Description("Page number (0-based)")),
+			mcp.WithNumber("page_size", mcp.Description("Number of results per page (max 100)")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			params := fiken.BuildQueryParams(
+				"page", args["page"],
+				"pageSize", args["page_size"],
+			)
+			body, status, err := client.Get("/companies/"+slug+"/timeUsers", params)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			return mcp.NewToolResultText(string(body)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("create_invoice_draft_from_time_entries",
+			mcp.WithDescription("Creates an invoice draft from a project's unbilled time entries, with one line per activity "+
+				"priced at its minutes × the hourly rate. The entries are then marked as invoiced, so running the tool again does "+
+				"not bill them twice; entries that could not be marked are listed in the result. "+
+				"Review the draft and use create_invoice_from_draft to issue it"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("project_id", mcp.Required(), mcp.Description("The project whose time entries should be invoiced")),
+			mcp.WithString("customer_id", mcp.Required(), mcp.Description("The contact ID of the customer to invoice")),
+			mcp.WithNumber("hourly_rate", mcp.Required(), mcp.Description("Hourly rate in NOK, excluding VAT")),
+			mcp.WithString("from_date", mcp.Description("Only include entries on or after this date (YYYY-MM-DD)")),
+			mcp.WithString("to_date", mcp.Description("Only include entries on or before this date (YYYY-MM-DD)")),
+			mcp.WithString("vat_type", mcp.Description("VAT type for the lines (default HIGH)")),
+			mcp.WithString("income_account", mcp.Description("Income account for the lines (default 3000)")),
+			mcp.WithNumber("days_until_due_date", mcp.Description("Payment terms in days (default 14)")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			projectID, err := strconv.ParseInt(mcp.ExtractString(args, "project_id"), 10, 64)
+			if err != nil {
+				return mcp.NewToolResultError("project_id must be a numeric project ID"), nil
+			}
+			customerID, err := strconv.ParseInt(mcp.ExtractString(args, "customer_id"), 10, 64)
+			if err != nil {
+				return mcp.NewToolResultError("customer_id must be a numeric contact ID"), nil
+			}
+			rate := fiken.Amount(math.Round(mcp.ParseFloat64(req, "hourly_rate", 0) * 100))
+			if rate <= 0 {
+				return mcp.NewToolResultError("hourly_rate must be a positive amount in NOK"), nil
+			}
+			from := mcp.ExtractString(args, "from_date")
+			to := mcp.ExtractString(args, "to_date")
+
+			// The raw entries are kept so they can be written back whole.
+			var rawEntries []json.RawMessage
+			if err := client.GetAll("/companies/"+slug+"/timeEntries", nil, &rawEntries); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			entries := make([]fiken.TimeEntry, len(rawEntries))
+			raw := make(map[int64]json.RawMessage, len(rawEntries))
+			for i, r := range rawEntries {
+				if err := json.Unmarshal(r, &entries[i]); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("decoding time entry: %v", err)), nil
+				}
+				raw[entries[i].ID] = r
+			}
+			var activities []fiken.Activity
+			if err := client.GetAll("/companies/"+slug+"/activities", nil, &activities); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			names := make(map[int64]string, len(activities))
+			for _, a := range activities {
+				names[a.ID] = a.Name
+			}
+
+			var unbilled []fiken.TimeEntry
+			for _, e := range entries {
+				if e.ProjectID != projectID || e.Invoiced {
+					continue
+				}
+				if (from != "" && e.Date < from) || (to != "" && e.Date > to) {
+					continue
+				}
+				unbilled = append(unbilled, e)
+			}
+			if len(unbilled) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("no unbilled time entries found for project %d in the given period", projectID)), nil
+			}
+
+			vatType := mcp.ParseString(req, "vat_type", "HIGH")
+			incomeAccount := mcp.ParseString(req, "income_account", "3000")
+			lines, entryIDs := timeEntryInvoiceLines(unbilled, names, rate, vatType, incomeAccount)
+			draft := map[string]interface{}{
+				"type":             "invoice",
+				"customerId":       customerID,
+				"projectId":        projectID,
+				"issueDate":        time.Now().Format("2006-01-02"),
+				"daysUntilDueDate": mcp.ParseInt(req, "days_until_due_date", 14),
+				"lines":            lines,
+			}
+			reqBody, err := json.Marshal(draft)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, status, err := client.Post("/companies/"+slug+"/invoices/drafts", reqBody)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			result := map[string]interface{}{
+				"draft":        json.RawMessage(nonEmptyJSON(body)),
+				"lines":        lines,
+				"timeEntryIds": entryIDs,
+			}
+			// Mark the entries as invoiced so they are not billed again.
+			var unmarked []string
+			for _, e := range unbilled {
+				entryBody, err := markInvoiced(raw[e.ID])
+				if err != nil {
+					unmarked = append(unmarked, fmt.Sprintf("%d: %v", e.ID, err))
+					continue
+				}
+				path := fmt.Sprintf("/companies/%s/timeEntries/%d", slug, e.ID)
+				if respBody, status, err := client.Put(path, entryBody); err != nil {
+					unmarked = append(unmarked, fmt.Sprintf("%d: %v", e.ID, err))
+				} else if status >= 400 {
+					unmarked = append(unmarked, fmt.Sprintf("%d: API error %d: %s", e.ID, status, string(respBody)))
+				}
+			}
+			if len(unmarked) > 0 {
+				result["warning"] = "the draft was created, but these time entries could not be marked as invoiced; " +
+					"mark them with update_time_entry before running this tool again"
+				result["unmarkedTimeEntries"] = unmarked
+			}
+			return jsonResult(result)
+		},
+	)
+}
+
+// markInvoiced returns a time entry as fetched from Fiken with invoiced set.
+// The whole entry is kept, since a PUT replaces every field.
+func markInvoiced(entry json.RawMessage) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(entry))
+	dec.UseNumber()
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil {
+		return nil, err
+	}
+	m["invoiced"] = true
+	return json.Marshal(m)
+}
+
+// timeEntryInvoiceLines groups time entries by activity into invoice draft
+// lines. Each line is one unit priced at minutes × rate / 60, rounded once to
+// the øre, so no billed time is lost to rounding the hours; unitPrice is in
+// NOK and converted to øre by the client.
+func timeEntryInvoiceLines(entries []fiken.TimeEntry, activityNames map[int64]string, rate fiken.Amount, vatType, incomeAccount string) ([]map[string]interface{}, []int64) {
+	minutes := make(map[int64]int64)
+	var activityIDs []int64
+	var entryIDs []int64
+	for _, e := range entries {
+		if _, seen := minutes[e.ActivityID]; !seen {
+			activityIDs = append(activityIDs, e.ActivityID)
+		}
+		minutes[e.ActivityID] += e.Minutes
+		entryIDs = append(entryIDs, e.ID)
+	}
+	sort.Slice(activityIDs, func(i, j int) bool { return activityIDs[i] < activityIDs[j] })
+
+	lines := make([]map[string]interface{}, 0, len(activityIDs))
+	for _, id := range activityIDs {
+		name := activityNames[id]
+		if name == "" {
+			name = fmt.Sprintf("Activity %d", id)
+		}
+		m := minutes[id]
+		lines = append(lines, map[string]interface{}{
+			"description":   fmt.Sprintf("%s (%s at %s kr/h)", name, formatMinutes(m), rate),
+			"quantity":      1,
+			"unitPrice":     timeAmount(m, rate).NOK(),
+			"vatType":       vatType,
+			"incomeAccount": incomeAccount,
+		})
+	}
+	return lines, entryIDs
+}
+
+// timeAmount is minutes at an hourly rate, rounded half away from zero to
+// the øre.
+func timeAmount(minutes int64, rate fiken.Amount) fiken.Amount {
+	n := minutes * int64(rate)
+	if n < 0 {
+		return fiken.Amount((n - 30) / 60)
+	}
+	return fiken.Amount((n + 30) / 60)
+}
+
+// formatMinutes formats a duration as hours and minutes, e.g. "1 h 20 min".
+func formatMinutes(minutes int64) string {
+	h, m := minutes/60, minutes%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%d min", m)
+	case m == 0:
+		return fmt.Sprintf("%d h", h)
+	}
+	return fmt.Sprintf("%d h %d min", h, m)
+}
+
+// nonEmptyJSON returns body, or JSON null when Fiken answered with an empty
+// body (as it does for 201 Created), so it can be embedded in a result.
+func nonEmptyJSON(body []byte) []byte {
+	if len(body) == 0 {
+		return []byte("null")
+	}
+	return body
+}
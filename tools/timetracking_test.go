@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+func TestTimeEntryInvoiceLines(t *testing.T) {
+	entries := []fiken.TimeEntry{
+		{ID: 1, ActivityID: 2, Minutes: 20},
+		{ID: 2, ActivityID: 1, Minutes: 60},
+		{ID: 3, ActivityID: 2, Minutes: 60},
+		{ID: 4, ActivityID: 1, Minutes: 45},
+	}
+	lines, ids := timeEntryInvoiceLines(entries, map[int64]string{1: "Design"}, 100000, "HIGH", "3000")
+
+	if len(ids) != 4 || len(lines) != 2 {
+		t.Fatalf("expected 2 lines for 4 entries, got %d lines, ids %v", len(lines), ids)
+	}
+	tests := []struct {
+		description string
+		unitPrice   float64
+	}{
+		// 105 min at 1000 kr/h is 1750.00.
+		{"Design (1 h 45 min at 1000.00 kr/h)", 1750},
+		// 80 min at 1000 kr/h is 1333.33, not 1.33 h × 1000.
+		{"Activity 2 (1 h 20 min at 1000.00 kr/h)", 1333.33},
+	}
+	for i, tt := range tests {
+		l := lines[i]
+		if l["description"] != tt.description || l["unitPrice"] != tt.unitPrice || l["quantity"] != 1 {
+			t.Errorf("line %d: got %v, want description %q, unitPrice %v, quantity 1", i, l, tt.description, tt.unitPrice)
+		}
+	}
+}
+
+func TestTimeAmount(t *testing.T) {
+	tests := []struct {
+		minutes int64
+		rate    fiken.Amount
+		want    fiken.Amount
+	}{
+		{20, 100000, 33333},
+		{40, 100000, 66667},
+		{60, 99950, 99950},
+		{1, 1, 0},
+		{-20, 100000, -33333},
+	}
+	for _, tt := range tests {
+		if got := timeAmount(tt.minutes, tt.rate); got != tt.want {
+			t.Errorf("timeAmount(%d, %s) = %s, want %s", tt.minutes, tt.rate, got, tt.want)
+		}
+	}
+}
+
+func TestMarkInvoicedKeepsAllFields(t *testing.T) {
+	entry := json.RawMessage(`{"id":7,"date":"2026-03-02","minutes":90,"activityId":1,"projectId":2,"timeUserId":3,` +
+		`"invoiced":false,"start":"08:00","end":"09:30","hourlyRate":1000.5,"tags":["onsite"]}`)
+	out, err := markInvoiced(entry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("invalid body %s: %v", out, err)
+	}
+	if got["invoiced"] != true {
+		t.Errorf("invoiced = %v, want true", got["invoiced"])
+	}
+	if got["start"] != "08:00" || got["end"] != "09:30" || got["hourlyRate"] != 1000.5 || len(got["tags"].([]interface{})) != 1 || got["minutes"] != 90.0 {
+		t.Errorf("fields were lost or changed: %s", out)
+	}
+}
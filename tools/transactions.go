@@ -2,6 +2,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -54,4 +55,93 @@ func registerTransactionTools(s *server.MCPServer, client *fiken.Client) {
 			return mcp.NewToolResultText(string(body)), nil
 		},
 	)
+
+	s.AddTool(
+		mcp.NewTool("get_transaction",
+			mcp.WithDescription("Returns a specific transaction with its journal entries and their posting lines"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("transaction_id", mcp.Required(), mcp.Description("The transaction ID")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "transaction_id")
+			body, status, err := client.Get("/companies/"+slug+"/transactions/"+id, nil)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+
+			// The transaction lists its journal entries, but depending on how the
+			// voucher was created the entries may come without lines. Fetch any such
+			// entry individually so the caller sees every posting in one response.
+			var transaction map[string]interface{}
+			if err := json.Unmarshal(body, &transaction); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("parsing transaction: %v", err)), nil
+			}
+			entries, _ := transaction["entries"].([]interface{})
+			for i, e := range entries {
+				entry, ok := e.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if _, hasLines := entry["lines"]; hasLines {
+					continue
+				}
+				entryID, ok := entry["journalEntryId"].(float64)
+				if !ok {
+					continue
+				}
+				entryBody, status, err := client.Get(fmt.Sprintf("/companies/%s/journalEntries/%.0f", slug, entryID), nil)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if status >= 400 {
+					return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(entryBody))), nil
+				}
+				var expanded map[string]interface{}
+				if err := json.Unmarshal(entryBody, &expanded); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("parsing journal entry: %v", err)), nil
+				}
+				entries[i] = expanded
+			}
+			out, err := json.Marshal(transaction)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return mcp.NewToolResultText(string(out)), nil
+		},
+	)
+
+	s.AddTool(
+		mcp.NewTool("delete_transaction",
+			mcp.WithDescription("Deletes a transaction. Fiken books a reversal of all its journal entries and records the given reason"),
+			mcp.WithString("company_slug", mcp.Required(), mcp.Description("The company slug identifier")),
+			mcp.WithString("transaction_id", mcp.Required(), mcp.Description("The transaction ID")),
+			mcp.WithString("description", mcp.Required(), mcp.Description("Reason for deleting the transaction; recorded with the reversal")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			slug := mcp.ExtractString(args, "company_slug")
+			id := mcp.ExtractString(args, "transaction_id")
+			description := mcp.ExtractString(args, "description")
+			if description == "" {
+				return mcp.NewToolResultError("description is required when deleting a transaction"), nil
+			}
+			params := fiken.BuildQueryParams("description", description)
+			body, status, err := client.Patch("/companies/"+slug+"/transactions/"+id+"/delete", nil, params)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status >= 400 {
+				return mcp.NewToolResultError(fmt.Sprintf("API error %d: %s", status, string(body))), nil
+			}
+			if len(body) > 0 {
+				return mcp.NewToolResultText(string(body)), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Transaction %s deleted successfully", id)), nil
+		},
+	)
 }
@@ -0,0 +1,96 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/simenandre/fiken-mcp/internal/fiken"
+)
+
+// validateContactBody checks the organization number and bank account number
+// of a contact request body. Norwegian rules only apply when the contact's
+// country is Norway or not given; an account number starting with letters is
+// checked as an IBAN.
+func validateContactBody(body string) error {
+	var c struct {
+		OrganizationNumber string `json:"organizationNumber"`
+		BankAccountNumber  string `json:"bankAccountNumber"`
+		Address            *struct {
+			Country string `json:"country"`
+		} `json:"address"`
+	}
+	if err := json.Unmarshal([]byte(body), &c); err != nil {
+		return nil
+	}
+	norwegian := c.Address == nil || isNorway(c.Address.Country)
+	if c.OrganizationNumber != "" && norwegian {
+		if err := fiken.ValidateOrganizationNumber(c.OrganizationNumber); err != nil {
+			return fmt.Errorf("organizationNumber: %w", err)
+		}
+	}
+	if c.BankAccountNumber != "" {
+		if err := validateAccountOrIBAN(c.BankAccountNumber, norwegian); err != nil {
+			return fmt.Errorf("bankAccountNumber: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateBankAccountBody checks the account number, IBAN and BIC of a bank
+// account request body. The account number of a FOREIGN account is only
+// checked when it is an IBAN.
+func validateBankAccountBody(body string) error {
+	var a struct {
+		BankAccountNumber string `json:"bankAccountNumber"`
+		Iban              string `json:"iban"`
+		Bic               string `json:"bic"`
+		Type              string `json:"type"`
+	}
+	if err := json.Unmarshal([]byte(body), &a); err != nil {
+		return nil
+	}
+	if a.BankAccountNumber != "" {
+		if err := validateAccountOrIBAN(a.BankAccountNumber, a.Type != "FOREIGN"); err != nil {
+			return fmt.Errorf("bankAccountNumber: %w", err)
+		}
+	}
+	if a.Iban != "" {
+		if err := fiken.ValidateIBAN(a.Iban); err != nil {
+			return fmt.Errorf("iban: %w", err)
+		}
+	}
+	if a.Bic != "" {
+		if err := fiken.ValidateBIC(a.Bic); err != nil {
+			return fmt.Errorf("bic: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateAccountOrIBAN checks an IBAN, or a Norwegian account number when
+// norwegian is set.
+func validateAccountOrIBAN(s string, norwegian bool) error {
+	n := fiken.NormalizeIdentifier(s)
+	if len(n) >= 2 && n[0] >= 'A' && n[0] <= 'Z' {
+		return fiken.ValidateIBAN(s)
+	}
+	if norwegian {
+		return fiken.ValidateBankAccountNumber(s)
+	}
+	return nil
+}
+
+func isNorway(country string) bool {
+	switch strings.ToUpper(strings.TrimSpace(country)) {
+	case "", "NO", "NOR", "NORWAY", "NORGE", "NOREG":
+		return true
+	}
+	return false
+}
+
+// validationErrorResult reports a request body that failed local validation.
+func validationErrorResult(err error) *mcp.CallToolResult {
+	return mcp.NewToolResultError("invalid request body: " + err.Error())
+}